@@ -0,0 +1,52 @@
+// Command ik_ga_leg solves toe-target inverse kinematics for the 3-DoF leg
+// in examples/hexapod_leg using the genetic-algorithm solver in ik/ga.
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"goikga/ik/ga"
+	"goikga/pga"
+)
+
+func main() {
+	l1, l2, l3 := 0.05, 0.20, 0.20
+	hip := pga.V(0, 0, 0)
+	thighJ := hip.Add(pga.V(l1, 0, 0))
+	kneeJ := thighJ.Add(pga.V(l2, 0, 0))
+
+	joints := []ga.AxisJoint{
+		{Axis: pga.V(0, 0, 1), Point: hip},
+		{Axis: pga.V(0, 1, 0), Point: thighJ},
+		{Axis: pga.V(0, 1, 0), Point: kneeJ},
+	}
+	toeOffset := pga.V(l3, 0, 0)
+
+	// A reachable target pose: same angles examples/hexapod_leg uses, so we
+	// know the solver's answer should land near theta = (20, -10, 30) deg.
+	target := ga.RevoluteChain(joints, toeOffset)([]float64{
+		20 * math.Pi / 180, -10 * math.Pi / 180, 30 * math.Pi / 180,
+	})
+
+	solver := ga.Solver{
+		Popsize:   60,
+		Elitism:   4,
+		Mutation:  0.1,
+		Crossover: 0.9,
+		MaxGen:    40,
+		Bounds: [][2]float64{
+			{-math.Pi, math.Pi},
+			{-math.Pi, math.Pi},
+			{-math.Pi, math.Pi},
+		},
+		Seed: 1,
+	}
+
+	q, residual := solver.SolveAxes(joints, toeOffset, target, 1.0, 1.0)
+	fmt.Printf("Solved joint angles (rad): %+v\n", q)
+	fmt.Printf("Residual: %g\n", residual)
+
+	toe := ga.RevoluteChain(joints, toeOffset)(q).ActPoint(pga.V(0, 0, 0))
+	fmt.Printf("Toe at solution: %+v\n", toe)
+}
@@ -0,0 +1,55 @@
+// Command multibody_demo builds the same 3-DoF leg as examples/hexapod_leg
+// as a multibody.Tree and prints the results of ForwardKinematics,
+// SpatialJacobian and InverseDynamics for it.
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"goikga/multibody"
+	"goikga/pga"
+)
+
+func main() {
+	l1, l2, l3 := 0.05, 0.20, 0.20
+	z := pga.V(0, 0, 1)
+	y := pga.V(0, 1, 0)
+
+	tree := &multibody.Tree{Links: []multibody.Link{
+		{
+			Parent:        -1,
+			JointToParent: multibody.RevoluteJoint{Axis: z, Point: pga.V(0, 0, 0)},
+			RestMotor:     pga.Identity(),
+			Body:          multibody.Body{Mass: 0.3, InertiaTensor: pga.Diag3(1e-4, 1e-4, 1e-4), Com: pga.V(l1/2, 0, 0)},
+		},
+		{
+			Parent:        0,
+			JointToParent: multibody.RevoluteJoint{Axis: y, Point: pga.V(0, 0, 0)},
+			RestMotor:     pga.Translator(pga.V(l1, 0, 0)),
+			Body:          multibody.Body{Mass: 0.5, InertiaTensor: pga.Diag3(2e-4, 2e-4, 2e-4), Com: pga.V(l2/2, 0, 0)},
+		},
+		{
+			Parent:        1,
+			JointToParent: multibody.RevoluteJoint{Axis: y, Point: pga.V(0, 0, 0)},
+			RestMotor:     pga.Translator(pga.V(l2, 0, 0)),
+			Body:          multibody.Body{Mass: 0.2, InertiaTensor: pga.Diag3(1e-4, 1e-4, 1e-4), Com: pga.V(l3/2, 0, 0)},
+		},
+	}}
+
+	q := []float64{20 * math.Pi / 180, -10 * math.Pi / 180, 30 * math.Pi / 180}
+	qdot := []float64{0, 0, 0}
+	qddot := []float64{0, 0, 0}
+
+	world := tree.ForwardKinematics(q)
+	toe := world[2].ActPoint(pga.V(l3, 0, 0))
+	fmt.Printf("Toe: %+v\n", toe)
+
+	linear, angular := tree.SpatialJacobian(q, 2, pga.V(l3, 0, 0))
+	fmt.Printf("Jacobian linear columns: %+v\n", linear)
+	fmt.Printf("Jacobian angular columns: %+v\n", angular)
+
+	gravity := pga.V(0, 0, -9.81)
+	tau := tree.InverseDynamics(q, qdot, qddot, gravity)
+	fmt.Printf("Static-hold joint torques (gravity compensation): %+v\n", tau)
+}
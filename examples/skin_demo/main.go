@@ -0,0 +1,47 @@
+// Command skin_demo loads the rigged glTF fixture in leg.gltf (a 3-DoF
+// hip/thigh/knee chain mirroring examples/hexapod_leg) via skin.LoadGLTF,
+// samples its "hip_swing" animation with a skin.Animator, and prints the
+// skinned toe vertex position before and after the hip rotates -- exercising
+// the full LoadGLTF -> Animator.Sample -> Skeleton.WorldMotors -> skin.Deform
+// pipeline against a real asset instead of a skeleton built by hand.
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"runtime"
+
+	"goikga/pga"
+	"goikga/skin"
+)
+
+func main() {
+	_, self, _, _ := runtime.Caller(0)
+	gltfPath := filepath.Join(filepath.Dir(self), "leg.gltf")
+
+	skel, doc, err := skin.LoadGLTF(gltfPath)
+	if err != nil {
+		log.Fatalf("LoadGLTF: %v", err)
+	}
+	anim, err := skin.NewAnimator(skel, doc, 0)
+	if err != nil {
+		log.Fatalf("NewAnimator: %v", err)
+	}
+
+	// Bind-pose (model-space) vertices: the hip origin, and the toe tip
+	// offset l1+l2+l3 = 0.45m along x from the hip, bound entirely to the
+	// knee joint.
+	vertices := []pga.Vec3{pga.V(0, 0, 0), pga.V(0.45, 0, 0)}
+	influences := [][]skin.Influence{
+		{{Joint: 0, Weight: 1}}, // hip vertex
+		{{Joint: 2, Weight: 1}}, // toe vertex, bound to the knee
+	}
+
+	for _, t := range []float64{0, 1} {
+		pose := anim.Sample(t)
+		motors := skel.WorldMotors(pose)
+		deformed := skin.Deform(vertices, influences, motors)
+		fmt.Printf("t=%.1fs  hip=%+v  toe=%+v\n", t, deformed[0], deformed[1])
+	}
+}
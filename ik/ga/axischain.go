@@ -0,0 +1,87 @@
+package ga
+
+import "goikga/pga"
+
+// AxisJoint describes one joint of a serial revolute/prismatic chain: a
+// revolute joint rotates about Axis through Point, a prismatic joint
+// translates along Axis (Point is unused).
+type AxisJoint struct {
+	Axis, Point pga.Vec3
+	Prismatic   bool
+}
+
+// RevoluteChain builds a Chain (for use with Solver.Solve) that composes
+// joints in order and appends a fixed toeOffset translation, mirroring the
+// hand-written motor chain in examples/hexapod_leg.
+func RevoluteChain(joints []AxisJoint, toeOffset pga.Vec3) Chain {
+	return func(q []float64) pga.Motor {
+		m := pga.Identity()
+		for i, j := range joints {
+			if j.Prismatic {
+				m = m.Mul(pga.Translator(j.Axis.Normalized().Scale(q[i])))
+			} else {
+				m = m.Mul(pga.Screw(j.Point, j.Axis, q[i], 0))
+			}
+		}
+		return m.Mul(pga.Translator(toeOffset))
+	}
+}
+
+// SolveAxes is a convenience wrapper around Solver.Solve for chains built
+// from known joint axes: its Lamarckian refinement uses the exact analytic
+// Jacobian from pga.RevoluteColumn/PrismaticColumn instead of finite
+// differences, which is both faster and what the backlog request calls for.
+func (s Solver) SolveAxes(joints []AxisJoint, toeOffset pga.Vec3, targetPose pga.Motor, weightPos, weightRot float64) ([]float64, float64) {
+	chain := RevoluteChain(joints, toeOffset)
+	return s.solveWithJacobian(chain, targetPose, weightPos, weightRot, func(q []float64) [][]float64 {
+		// The refinement residual is Log(chain(q).Inv().Mul(target)), which is
+		// in the toe frame and carries half the rotation angle; re-express the
+		// world-frame columns the same way.
+		J := axisJacobian(joints, toeOffset, q)
+		inv := chain(q).Inv()
+		for c := range q {
+			ang := inv.ActDir(pga.V(J[0][c], J[1][c], J[2][c])).Scale(0.5)
+			lin := inv.ActDir(pga.V(J[3][c], J[4][c], J[5][c])).Scale(0.5)
+			J[0][c], J[1][c], J[2][c] = ang.X, ang.Y, ang.Z
+			J[3][c], J[4][c], J[5][c] = lin.X, lin.Y, lin.Z
+		}
+		return J
+	})
+}
+
+// axisJacobian returns the 6xN Jacobian (3 angular rows, 3 linear rows) of
+// the chain's toe point with respect to q, built directly from
+// pga.RevoluteColumn/pga.PrismaticColumn the same way examples/hexapod_leg
+// does it by hand.
+func axisJacobian(joints []AxisJoint, toeOffset pga.Vec3, q []float64) [][]float64 {
+	prefix := make([]pga.Motor, len(joints)+1)
+	prefix[0] = pga.Identity()
+	for i, j := range joints {
+		if j.Prismatic {
+			prefix[i+1] = prefix[i].Mul(pga.Translator(j.Axis.Normalized().Scale(q[i])))
+		} else {
+			prefix[i+1] = prefix[i].Mul(pga.Screw(j.Point, j.Axis, q[i], 0))
+		}
+	}
+	toe := prefix[len(joints)].Mul(pga.Translator(toeOffset)).ActPoint(pga.V(0, 0, 0))
+
+	J := make([][]float64, 6)
+	for r := range J {
+		J[r] = make([]float64, len(joints))
+	}
+	for i, j := range joints {
+		axisWorld := prefix[i].ActDir(j.Axis.Normalized())
+		var lin, ang pga.Vec3
+		if j.Prismatic {
+			lin = pga.PrismaticColumn(axisWorld)
+			ang = pga.V(0, 0, 0)
+		} else {
+			pointWorld := prefix[i].ActPoint(j.Point)
+			lin = pga.RevoluteColumn(pointWorld, axisWorld, toe)
+			ang = axisWorld
+		}
+		J[0][i], J[1][i], J[2][i] = ang.X, ang.Y, ang.Z
+		J[3][i], J[4][i], J[5][i] = lin.X, lin.Y, lin.Z
+	}
+	return J
+}
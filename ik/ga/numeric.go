@@ -0,0 +1,193 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+
+	"goikga/pga"
+)
+
+// refineDLS nudges q towards target via a few damped least-squares Newton
+// steps using a numerically-differenced Jacobian of chain, the generic
+// fallback for when chain isn't built from known joint axes (see
+// SolveAxes/axisJacobian in axischain.go for the analytic,
+// RevoluteColumn-based version used by the leg example).
+func refineDLS(chain Chain, target pga.Motor, q []float64, bounds [][2]float64, weightPos, weightRot float64, iters int) {
+	const h = 1e-4
+	jacobianFn := func(q []float64) [][]float64 {
+		base := chain(q)
+		n := len(q)
+		J := make([][]float64, 6)
+		for r := range J {
+			J[r] = make([]float64, n)
+		}
+		for g := 0; g < n; g++ {
+			qh := append([]float64(nil), q...)
+			qh[g] += h
+			d := base.Inv().Mul(chain(qh)).Log()
+			J[0][g], J[1][g], J[2][g] = d.W.X/h, d.W.Y/h, d.W.Z/h
+			J[3][g], J[4][g], J[5][g] = d.Wd.X/h, d.Wd.Y/h, d.Wd.Z/h
+		}
+		return J
+	}
+	refineDLSAnalytic(chain, target, q, bounds, weightPos, weightRot, iters, jacobianFn)
+}
+
+// refineDLSAnalytic is refineDLS's shared core, parameterized on how the
+// raw (unweighted) Jacobian -- 3 angular rows then 3 linear rows -- is
+// obtained; weightRot/weightPos scale the rows before the damped
+// least-squares solve.
+func refineDLSAnalytic(chain Chain, target pga.Motor, q []float64, bounds [][2]float64, weightPos, weightRot float64, iters int, jacobianFn func(q []float64) [][]float64) {
+	sr, sp := math.Sqrt(weightRot), math.Sqrt(weightPos)
+	for it := 0; it < iters; it++ {
+		base := chain(q)
+		targetLocal := base.Inv().Mul(target).Log()
+		J := jacobianFn(q)
+		for g := range q {
+			J[0][g] *= sr
+			J[1][g] *= sr
+			J[2][g] *= sr
+			J[3][g] *= sp
+			J[4][g] *= sp
+			J[5][g] *= sp
+		}
+		b := []float64{
+			targetLocal.W.X * sr, targetLocal.W.Y * sr, targetLocal.W.Z * sr,
+			targetLocal.Wd.X * sp, targetLocal.Wd.Y * sp, targetLocal.Wd.Z * sp,
+		}
+		dq := dampedLeastSquares(J, b, 1e-2)
+		for g := range q {
+			q[g] += dq[g]
+			if q[g] < bounds[g][0] {
+				q[g] = bounds[g][0]
+			} else if q[g] > bounds[g][1] {
+				q[g] = bounds[g][1]
+			}
+		}
+	}
+}
+
+// dampedLeastSquares solves (J^T J + lambda I) dq = J^T b for dq, where J
+// has one row per residual and one column per generalized coordinate.
+func dampedLeastSquares(J [][]float64, b []float64, lambda float64) []float64 {
+	rows := len(J)
+	n := len(J[0])
+
+	jtj := make([][]float64, n)
+	jtb := make([]float64, n)
+	for i := 0; i < n; i++ {
+		jtj[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			s := 0.0
+			for r := 0; r < rows; r++ {
+				s += J[r][i] * J[r][j]
+			}
+			jtj[i][j] = s
+		}
+		jtj[i][i] += lambda
+		s := 0.0
+		for r := 0; r < rows; r++ {
+			s += J[r][i] * b[r]
+		}
+		jtb[i] = s
+	}
+	return solveLinear(jtj, jtb)
+}
+
+// solveLinear solves Ax=b via Gaussian elimination with partial pivoting.
+func solveLinear(a [][]float64, b []float64) []float64 {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = append(append([]float64(nil), a[i]...), b[i])
+	}
+	for col := 0; col < n; col++ {
+		piv := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(m[r][col]) > math.Abs(m[piv][col]) {
+				piv = r
+			}
+		}
+		m[col], m[piv] = m[piv], m[col]
+		if math.Abs(m[col][col]) < 1e-12 {
+			continue
+		}
+		for r := col + 1; r < n; r++ {
+			f := m[r][col] / m[col][col]
+			for c := col; c <= n; c++ {
+				m[r][c] -= f * m[col][c]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := m[i][n]
+		for j := i + 1; j < n; j++ {
+			s -= m[i][j] * x[j]
+		}
+		if math.Abs(m[i][i]) < 1e-12 {
+			x[i] = 0
+			continue
+		}
+		x[i] = s / m[i][i]
+	}
+	return x
+}
+
+// sbxCrossover performs simulated binary crossover between two parents.
+func sbxCrossover(p1, p2 []float64, bounds [][2]float64, rng *rand.Rand) (c1, c2 []float64) {
+	const eta = 15.0
+	c1 = make([]float64, len(p1))
+	c2 = make([]float64, len(p1))
+	for i := range p1 {
+		u := rng.Float64()
+		var beta float64
+		if u <= 0.5 {
+			beta = math.Pow(2*u, 1/(eta+1))
+		} else {
+			beta = math.Pow(1/(2*(1-u)), 1/(eta+1))
+		}
+		x1, x2 := p1[i], p2[i]
+		c1[i] = clamp(0.5*((1+beta)*x1+(1-beta)*x2), bounds[i])
+		c2[i] = clamp(0.5*((1-beta)*x1+(1+beta)*x2), bounds[i])
+	}
+	return c1, c2
+}
+
+// polynomialMutate mutates q in place, one gene at a time with probability
+// pm, using polynomial mutation.
+func polynomialMutate(q []float64, bounds [][2]float64, pm float64, rng *rand.Rand) {
+	const etaM = 20.0
+	for i := range q {
+		if rng.Float64() > pm {
+			continue
+		}
+		lo, hi := bounds[i][0], bounds[i][1]
+		if hi <= lo {
+			continue
+		}
+		x := q[i]
+		delta1 := (x - lo) / (hi - lo)
+		delta2 := (hi - x) / (hi - lo)
+		u := rng.Float64()
+		var deltaq float64
+		if u < 0.5 {
+			val := 2*u + (1-2*u)*math.Pow(1-delta1, etaM+1)
+			deltaq = math.Pow(val, 1/(etaM+1)) - 1
+		} else {
+			val := 2*(1-u) + 2*(u-0.5)*math.Pow(1-delta2, etaM+1)
+			deltaq = 1 - math.Pow(val, 1/(etaM+1))
+		}
+		q[i] = clamp(x+deltaq*(hi-lo), bounds[i])
+	}
+}
+
+func clamp(x float64, bound [2]float64) float64 {
+	if x < bound[0] {
+		return bound[0]
+	}
+	if x > bound[1] {
+		return bound[1]
+	}
+	return x
+}
@@ -0,0 +1,131 @@
+// Package ga solves inverse kinematics for an arbitrary motor chain by
+// evolving joint-angle vectors with a genetic algorithm, the "ga" the
+// goik-ga module is named for. A Lamarckian damped-least-squares refinement
+// step on each generation's elite individuals couples the GA to the
+// existing Jacobian-column helpers in package pga, converging much faster
+// than a pure GA on its own.
+package ga
+
+import (
+	"math/rand"
+	"sort"
+
+	"goikga/pga"
+)
+
+// Solver configures the genetic algorithm.
+type Solver struct {
+	Popsize   int
+	Elitism   int
+	Mutation  float64 // per-gene mutation probability
+	Crossover float64 // per-individual crossover probability
+	MaxGen    int
+	Bounds    [][2]float64 // one [lo,hi] pair per gene (joint angle/offset)
+	Seed      int64
+}
+
+// Chain is the black-box forward-kinematics function an individual's genes
+// (joint values) are evaluated through.
+type Chain func(q []float64) pga.Motor
+
+type individual struct {
+	q       []float64
+	fitness float64
+}
+
+// Solve evolves a population of joint-angle vectors to minimize the pose
+// error between chain(q) and targetPose, where the error is the norm of
+// Log(target.Inv().Mul(chain(q))) split into its rotational (bivector W)
+// and translational (Wd) parts, weighted by weightRot/weightPos. The
+// Lamarckian refinement step differences chain numerically; for chains
+// built from known joint axes, SolveAxes gives the same result faster by
+// using an analytic Jacobian instead.
+func (s Solver) Solve(chain Chain, targetPose pga.Motor, weightPos, weightRot float64) ([]float64, float64) {
+	refine := func(q []float64) {
+		refineDLS(chain, targetPose, q, s.Bounds, weightPos, weightRot, 3)
+	}
+	return s.solveCore(chain, targetPose, weightPos, weightRot, refine)
+}
+
+// solveWithJacobian is Solve's core but with the Lamarckian refinement
+// driven by an analytic Jacobian (jacobianFn) instead of finite differences.
+func (s Solver) solveWithJacobian(chain Chain, targetPose pga.Motor, weightPos, weightRot float64, jacobianFn func(q []float64) [][]float64) ([]float64, float64) {
+	refine := func(q []float64) {
+		refineDLSAnalytic(chain, targetPose, q, s.Bounds, weightPos, weightRot, 3, jacobianFn)
+	}
+	return s.solveCore(chain, targetPose, weightPos, weightRot, refine)
+}
+
+func (s Solver) solveCore(chain Chain, targetPose pga.Motor, weightPos, weightRot float64, refine func(q []float64)) ([]float64, float64) {
+	rng := rand.New(rand.NewSource(s.Seed))
+	n := len(s.Bounds)
+
+	fitness := func(q []float64) float64 {
+		d := targetPose.Inv().Mul(chain(q)).Log()
+		return weightRot*d.W.Norm() + weightPos*d.Wd.Norm()
+	}
+
+	pop := make([]individual, s.Popsize)
+	for i := range pop {
+		q := make([]float64, n)
+		for g := range q {
+			lo, hi := s.Bounds[g][0], s.Bounds[g][1]
+			q[g] = lo + rng.Float64()*(hi-lo)
+		}
+		pop[i] = individual{q: q, fitness: fitness(q)}
+	}
+	sortByFitness(pop)
+
+	for gen := 0; gen < s.MaxGen; gen++ {
+		// Lamarckian local search: refine the elites in place with a few
+		// damped least-squares steps, then re-sort so the GA's selection
+		// pressure sees the improved genomes too.
+		for i := 0; i < s.Elitism && i < len(pop); i++ {
+			refine(pop[i].q)
+			pop[i].fitness = fitness(pop[i].q)
+		}
+		sortByFitness(pop)
+
+		next := make([]individual, 0, s.Popsize)
+		for i := 0; i < s.Elitism && i < len(pop); i++ {
+			next = append(next, pop[i])
+		}
+		for len(next) < s.Popsize {
+			p1 := tournamentSelect(pop, rng)
+			p2 := tournamentSelect(pop, rng)
+			c1, c2 := p1.q, p2.q
+			if rng.Float64() < s.Crossover {
+				c1, c2 = sbxCrossover(p1.q, p2.q, s.Bounds, rng)
+			} else {
+				c1, c2 = append([]float64(nil), p1.q...), append([]float64(nil), p2.q...)
+			}
+			polynomialMutate(c1, s.Bounds, s.Mutation, rng)
+			polynomialMutate(c2, s.Bounds, s.Mutation, rng)
+			next = append(next, individual{q: c1, fitness: fitness(c1)})
+			if len(next) < s.Popsize {
+				next = append(next, individual{q: c2, fitness: fitness(c2)})
+			}
+		}
+		pop = next
+		sortByFitness(pop)
+	}
+
+	best := pop[0]
+	return best.q, best.fitness
+}
+
+func sortByFitness(pop []individual) {
+	sort.Slice(pop, func(i, j int) bool { return pop[i].fitness < pop[j].fitness })
+}
+
+func tournamentSelect(pop []individual, rng *rand.Rand) individual {
+	const k = 3
+	best := pop[rng.Intn(len(pop))]
+	for i := 1; i < k; i++ {
+		c := pop[rng.Intn(len(pop))]
+		if c.fitness < best.fitness {
+			best = c
+		}
+	}
+	return best
+}
@@ -0,0 +1,53 @@
+package ga
+
+import (
+	"math"
+	"testing"
+
+	"goikga/pga"
+)
+
+// TestSolveAxesConvergesOnReachableTarget checks that the GA+DLS solver
+// drives the hexapod_leg-shaped 3-DoF chain to a target pose reached by a
+// known joint configuration, within a small pose-error tolerance.
+func TestSolveAxesConvergesOnReachableTarget(t *testing.T) {
+	l1, l2, l3 := 0.05, 0.20, 0.20
+	hip := pga.V(0, 0, 0)
+	thighJ := hip.Add(pga.V(l1, 0, 0))
+	kneeJ := thighJ.Add(pga.V(l2, 0, 0))
+
+	joints := []AxisJoint{
+		{Axis: pga.V(0, 0, 1), Point: hip},
+		{Axis: pga.V(0, 1, 0), Point: thighJ},
+		{Axis: pga.V(0, 1, 0), Point: kneeJ},
+	}
+	toeOffset := pga.V(l3, 0, 0)
+
+	wantQ := []float64{0.3, -0.2, 0.4}
+	target := RevoluteChain(joints, toeOffset)(wantQ)
+
+	s := Solver{
+		Popsize:   60,
+		Elitism:   4,
+		Mutation:  0.1,
+		Crossover: 0.8,
+		MaxGen:    30,
+		Bounds:    [][2]float64{{-math.Pi, math.Pi}, {-math.Pi, math.Pi}, {-math.Pi, math.Pi}},
+		Seed:      1,
+	}
+
+	q, fitness := s.SolveAxes(joints, toeOffset, target, 1, 1)
+	if fitness > 1e-3 {
+		t.Fatalf("SolveAxes did not converge: fitness=%v, q=%v (target reached by %v)", fitness, q, wantQ)
+	}
+
+	got := RevoluteChain(joints, toeOffset)(q)
+	if d := pga.OrientationError(target, got).Norm(); d > 1e-2 {
+		t.Fatalf("solved pose orientation error = %v, want near 0", d)
+	}
+	gotToe := got.ActPoint(pga.V(0, 0, 0))
+	wantToe := target.ActPoint(pga.V(0, 0, 0))
+	if gotToe.Sub(wantToe).Norm() > 1e-2 {
+		t.Fatalf("solved toe position = %v, want %v", gotToe, wantToe)
+	}
+}
@@ -0,0 +1,31 @@
+// Package multibody turns the ad-hoc motor chain in examples/hexapod_leg
+// into a first-class kinematic/dynamic tree: typed joints expose their own
+// motor, spatial twist and Jacobian columns, and Tree composes them into
+// whole-body forward kinematics, Jacobians and inverse dynamics.
+package multibody
+
+import "goikga/pga"
+
+// Body carries a link's inertial properties, in its own local frame.
+type Body struct {
+	Mass          float64
+	InertiaTensor pga.Mat3 // about Com, in the link's local (rest) frame
+	Com           pga.Vec3
+}
+
+// Twist is a spatial velocity (or acceleration), split into its linear and
+// angular parts. Joint.Twist returns one in the joint's attachment frame;
+// Tree.InverseDynamics rotates those into world coordinates for its
+// per-body twists.
+type Twist struct {
+	Linear  pga.Vec3
+	Angular pga.Vec3
+}
+
+func (t Twist) Add(o Twist) Twist {
+	return Twist{Linear: t.Linear.Add(o.Linear), Angular: t.Angular.Add(o.Angular)}
+}
+
+func (t Twist) Scale(s float64) Twist {
+	return Twist{Linear: t.Linear.Scale(s), Angular: t.Angular.Scale(s)}
+}
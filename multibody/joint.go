@@ -0,0 +1,170 @@
+package multibody
+
+import "goikga/pga"
+
+// Joint is one degree-of-freedom-carrying connection between a link and its
+// parent. Axis/Point fields on the concrete joint types are expressed in the
+// frame the joint is attached to (i.e. the parent link's world frame
+// composed with the child's RestMotor, see Tree.jointFrames).
+type Joint interface {
+	// DoF is the number of generalized coordinates this joint consumes.
+	DoF() int
+	// Motor returns the joint's local transform for generalized coordinates
+	// q (len(q) == DoF()).
+	Motor(q []float64) pga.Motor
+	// Twist returns the joint's contribution to spatial velocity, expressed
+	// about the joint's own local origin, for coordinates q and rates qdot.
+	Twist(q, qdot []float64) Twist
+	// JacobianColumns returns, for each of the joint's DoF, the linear and
+	// angular velocity induced at toolPoint (world coordinates) by a unit
+	// rate of that coordinate, given the joint's attachment frame expressed
+	// in world coordinates as worldMotor.
+	JacobianColumns(worldMotor pga.Motor, toolPoint pga.Vec3) (linear, angular []pga.Vec3)
+}
+
+// RevoluteJoint rotates about Axis through Point (both in the joint's
+// attachment frame).
+type RevoluteJoint struct {
+	Axis, Point pga.Vec3
+}
+
+func (j RevoluteJoint) DoF() int { return 1 }
+
+func (j RevoluteJoint) Motor(q []float64) pga.Motor {
+	return pga.Screw(j.Point, j.Axis, q[0], 0)
+}
+
+func (j RevoluteJoint) Twist(q, qdot []float64) Twist {
+	u := j.Axis.Normalized()
+	omega := u.Scale(qdot[0])
+	return Twist{Angular: omega, Linear: u.Cross(j.Point.Neg()).Scale(qdot[0])}
+}
+
+func (j RevoluteJoint) JacobianColumns(worldMotor pga.Motor, toolPoint pga.Vec3) (linear, angular []pga.Vec3) {
+	axisWorld := worldMotor.ActDir(j.Axis.Normalized())
+	pointWorld := worldMotor.ActPoint(j.Point)
+	return []pga.Vec3{pga.RevoluteColumn(pointWorld, axisWorld, toolPoint)}, []pga.Vec3{axisWorld}
+}
+
+// PrismaticJoint translates along Axis (in the joint's attachment frame).
+type PrismaticJoint struct {
+	Axis pga.Vec3
+}
+
+func (j PrismaticJoint) DoF() int { return 1 }
+
+func (j PrismaticJoint) Motor(q []float64) pga.Motor {
+	return pga.Translator(j.Axis.Normalized().Scale(q[0]))
+}
+
+func (j PrismaticJoint) Twist(q, qdot []float64) Twist {
+	return Twist{Linear: j.Axis.Normalized().Scale(qdot[0])}
+}
+
+func (j PrismaticJoint) JacobianColumns(worldMotor pga.Motor, toolPoint pga.Vec3) (linear, angular []pga.Vec3) {
+	axisWorld := worldMotor.ActDir(j.Axis.Normalized())
+	return []pga.Vec3{axisWorld}, []pga.Vec3{{}}
+}
+
+// HelicalJoint couples rotation about Axis through Point to translation
+// along Axis by Pitch (distance per radian), as in nphysics' helical joint.
+type HelicalJoint struct {
+	Axis, Point pga.Vec3
+	Pitch       float64
+}
+
+func (j HelicalJoint) DoF() int { return 1 }
+
+func (j HelicalJoint) Motor(q []float64) pga.Motor {
+	return pga.Screw(j.Point, j.Axis, q[0], j.Pitch)
+}
+
+func (j HelicalJoint) Twist(q, qdot []float64) Twist {
+	u := j.Axis.Normalized()
+	rev := RevoluteJoint{Axis: j.Axis, Point: j.Point}.Twist(q, qdot)
+	return Twist{Angular: rev.Angular, Linear: rev.Linear.Add(u.Scale(j.Pitch * qdot[0]))}
+}
+
+func (j HelicalJoint) JacobianColumns(worldMotor pga.Motor, toolPoint pga.Vec3) (linear, angular []pga.Vec3) {
+	axisWorld := worldMotor.ActDir(j.Axis.Normalized())
+	pointWorld := worldMotor.ActPoint(j.Point)
+	lin := pga.RevoluteColumn(pointWorld, axisWorld, toolPoint).Add(axisWorld.Scale(j.Pitch))
+	return []pga.Vec3{lin}, []pga.Vec3{axisWorld}
+}
+
+// SphericalJoint is a 3-DoF ball joint: q is a rotation vector (exponential
+// coordinates, angle = |q|, axis = q/|q|) about Point, avoiding a 3-angle
+// Euler parameterization and its gimbal lock.
+type SphericalJoint struct {
+	Point pga.Vec3
+}
+
+func (j SphericalJoint) DoF() int { return 3 }
+
+func (j SphericalJoint) Motor(q []float64) pga.Motor {
+	v := pga.V(q[0], q[1], q[2])
+	theta := v.Norm()
+	rot := pga.Identity()
+	if theta > 1e-12 {
+		rot = pga.FromAxisAngle(v.Scale(1/theta), theta)
+	}
+	return pga.Translator(j.Point).Mul(rot).Mul(pga.Translator(j.Point.Neg()))
+}
+
+func (j SphericalJoint) Twist(q, qdot []float64) Twist {
+	omega := pga.V(qdot[0], qdot[1], qdot[2])
+	return Twist{Angular: omega, Linear: omega.Cross(j.Point.Neg())}
+}
+
+func (j SphericalJoint) JacobianColumns(worldMotor pga.Motor, toolPoint pga.Vec3) (linear, angular []pga.Vec3) {
+	pointWorld := worldMotor.ActPoint(j.Point)
+	axes := []pga.Vec3{pga.V(1, 0, 0), pga.V(0, 1, 0), pga.V(0, 0, 1)}
+	linear = make([]pga.Vec3, 3)
+	angular = make([]pga.Vec3, 3)
+	for i, a := range axes {
+		aw := worldMotor.ActDir(a)
+		angular[i] = aw
+		linear[i] = pga.RevoluteColumn(pointWorld, aw, toolPoint)
+	}
+	return linear, angular
+}
+
+// FreeJoint is an unconstrained 6-DoF joint. Its state is the bivector
+// logarithm of a motor (q[0:3]=rotation generator, q[3:6]=translation
+// generator), the same exponential-coordinate trick Multibody.jl uses to
+// keep a free-floating root singularity-free instead of Euler angles.
+type FreeJoint struct{}
+
+func (j FreeJoint) DoF() int { return 6 }
+
+func (j FreeJoint) Motor(q []float64) pga.Motor {
+	// Bivector.Exp treats |W| as a half-angle (see pga/screw.go), so q must
+	// be halved here to make |q[0:3]| the full rotation angle, matching
+	// SphericalJoint's convention and Twist's Angular = qdot[0:3] below.
+	b := pga.Bivector{
+		W:  pga.V(q[0], q[1], q[2]).Scale(0.5),
+		Wd: pga.V(q[3], q[4], q[5]).Scale(0.5),
+	}
+	return b.Exp()
+}
+
+func (j FreeJoint) Twist(q, qdot []float64) Twist {
+	return Twist{Angular: pga.V(qdot[0], qdot[1], qdot[2]), Linear: pga.V(qdot[3], qdot[4], qdot[5])}
+}
+
+func (j FreeJoint) JacobianColumns(worldMotor pga.Motor, toolPoint pga.Vec3) (linear, angular []pga.Vec3) {
+	origin := worldMotor.ActPoint(pga.V(0, 0, 0))
+	axes := []pga.Vec3{pga.V(1, 0, 0), pga.V(0, 1, 0), pga.V(0, 0, 1)}
+	linear = make([]pga.Vec3, 6)
+	angular = make([]pga.Vec3, 6)
+	for i, a := range axes {
+		aw := worldMotor.ActDir(a)
+		angular[i] = aw
+		linear[i] = aw.Cross(toolPoint.Sub(origin))
+		// Translational DoF i+3: pure linear motion along world axis aw, no
+		// rotation.
+		linear[i+3] = aw
+		angular[i+3] = pga.V(0, 0, 0)
+	}
+	return linear, angular
+}
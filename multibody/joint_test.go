@@ -0,0 +1,39 @@
+package multibody
+
+import (
+	"math"
+	"testing"
+
+	"goikga/pga"
+)
+
+// TestFreeJointMotorFullAngle checks that FreeJoint.Motor uses the same
+// full-angle convention as SphericalJoint: |q[0:3]| is the rotation angle,
+// not twice it.
+func TestFreeJointMotorFullAngle(t *testing.T) {
+	theta := 0.6
+	q := []float64{0, 0, theta, 0, 0, 0}
+	m := FreeJoint{}.Motor(q)
+
+	got := m.ActPoint(pga.V(1, 0, 0))
+	want := pga.V(math.Cos(theta), math.Sin(theta), 0)
+	if got.Sub(want).Norm() > 1e-9 {
+		t.Fatalf("FreeJoint{}.Motor(%v).ActPoint((1,0,0)) = %v, want %v (rotation by |q|=%v)", q, got, want, theta)
+	}
+}
+
+// TestFreeJointMotorMatchesSpherical checks FreeJoint and SphericalJoint
+// agree on rotation for the same q, since both report Twist.Angular =
+// qdot[0:3] and so must share a rotation-angle convention.
+func TestFreeJointMotorMatchesSpherical(t *testing.T) {
+	q := []float64{0.1, -0.2, 0.3}
+	free := FreeJoint{}.Motor(append(append([]float64{}, q...), 0, 0, 0))
+	spherical := SphericalJoint{}.Motor(q)
+
+	p := pga.V(1, 2, 3)
+	got := free.ActPoint(p)
+	want := spherical.ActPoint(p)
+	if got.Sub(want).Norm() > 1e-9 {
+		t.Fatalf("FreeJoint.Motor rotation = %v, SphericalJoint.Motor rotation = %v, want equal", got, want)
+	}
+}
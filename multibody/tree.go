@@ -0,0 +1,198 @@
+package multibody
+
+import "goikga/pga"
+
+// Link is one body in a Tree, attached to Parent (or -1 for a root) via
+// JointToParent. RestMotor is the link's rest-pose transform relative to its
+// parent, applied before the joint's own motion (the role the joint-center
+// offsets play in examples/hexapod_leg).
+type Link struct {
+	Parent        int
+	JointToParent Joint
+	RestMotor     pga.Motor
+	Body          Body
+}
+
+// Tree is a kinematic/dynamic tree of links, indexed so that Parent < index
+// for every non-root link (the same topological-order requirement as
+// skin.Skeleton).
+type Tree struct {
+	Links []Link
+}
+
+// NumDoF is the total number of generalized coordinates across all links.
+func (t *Tree) NumDoF() int {
+	n := 0
+	for _, l := range t.Links {
+		n += l.JointToParent.DoF()
+	}
+	return n
+}
+
+// offsets returns, for each link, the starting index of its coordinates
+// within a flat q/qdot/qddot vector of length NumDoF().
+func (t *Tree) offsets() []int {
+	off := make([]int, len(t.Links))
+	n := 0
+	for i, l := range t.Links {
+		off[i] = n
+		n += l.JointToParent.DoF()
+	}
+	return off
+}
+
+// jointFrames returns, for each link, the world motor of the frame its
+// joint's Axis/Point are defined in (parent world motor composed with the
+// link's RestMotor, i.e. the frame just before the joint's own DoF is
+// applied) and the link's resulting world motor.
+func (t *Tree) jointFrames(q []float64) (frames, world []pga.Motor) {
+	off := t.offsets()
+	frames = make([]pga.Motor, len(t.Links))
+	world = make([]pga.Motor, len(t.Links))
+	for i, l := range t.Links {
+		parentWorld := pga.Identity()
+		if l.Parent >= 0 {
+			parentWorld = world[l.Parent]
+		}
+		frames[i] = parentWorld.Mul(l.RestMotor)
+		qi := q[off[i] : off[i]+l.JointToParent.DoF()]
+		world[i] = frames[i].Mul(l.JointToParent.Motor(qi))
+	}
+	return frames, world
+}
+
+// ForwardKinematics returns each link's world-space motor for generalized
+// coordinates q.
+func (t *Tree) ForwardKinematics(q []float64) []pga.Motor {
+	_, world := t.jointFrames(q)
+	return world
+}
+
+// SpatialJacobian returns the linear and angular velocity columns, one per
+// generalized coordinate (zero for coordinates that aren't ancestors of
+// toolLink), of toolPoint (in toolLink's local frame) with respect to q.
+func (t *Tree) SpatialJacobian(q []float64, toolLink int, toolPoint pga.Vec3) (linear, angular []pga.Vec3) {
+	frames, world := t.jointFrames(q)
+	off := t.offsets()
+	n := t.NumDoF()
+	linear = make([]pga.Vec3, n)
+	angular = make([]pga.Vec3, n)
+
+	toolWorld := world[toolLink].ActPoint(toolPoint)
+
+	// Walk from toolLink up to the root, filling in each ancestor's columns.
+	for i := toolLink; i >= 0; i = t.Links[i].Parent {
+		l := t.Links[i]
+		lin, ang := l.JointToParent.JacobianColumns(frames[i], toolWorld)
+		for k := 0; k < l.JointToParent.DoF(); k++ {
+			linear[off[i]+k] = lin[k]
+			angular[off[i]+k] = ang[k]
+		}
+		if l.Parent < 0 {
+			break
+		}
+	}
+	return linear, angular
+}
+
+// InverseDynamics computes the generalized joint forces/torques needed to
+// realize accelerations qddot given positions q, rates qdot and a world
+// gravity vector, via a recursive Newton-Euler sweep: a forward pass
+// accumulates each body's world velocity/acceleration from its parent's,
+// and a backward pass accumulates each body's net wrench (folding in its
+// children's reaction wrenches) and projects it onto each joint's Jacobian
+// columns to get the generalized force.
+func (t *Tree) InverseDynamics(q, qdot, qddot []float64, gravity pga.Vec3) []float64 {
+	off := t.offsets()
+	n := len(t.Links)
+	frames, world := t.jointFrames(q)
+
+	vel := make([]Twist, n)
+	acc := make([]Twist, n)
+	// Fold gravity into the base (root) linear acceleration, the standard
+	// RNEA trick that avoids adding a gravity force to every body.
+	baseAcc := Twist{Linear: gravity.Neg()}
+
+	for i, l := range t.Links {
+		qi := q[off[i] : off[i]+l.JointToParent.DoF()]
+		qdi := qdot[off[i] : off[i]+l.JointToParent.DoF()]
+		qddi := qddot[off[i] : off[i]+l.JointToParent.DoF()]
+		jt := l.JointToParent.Twist(qi, qdi)
+		jtAcc := l.JointToParent.Twist(qi, qddi)
+
+		parentVel, parentAcc := Twist{}, baseAcc
+		if l.Parent >= 0 {
+			parentVel, parentAcc = vel[l.Parent], acc[l.Parent]
+		}
+		// Angular velocity/acceleration add directly (all expressed in
+		// world coordinates via the joint's attachment frame); the
+		// Coriolis term omega x jointLinear is folded into jtAcc's linear
+		// part approximately by reusing the joint's own twist formula at
+		// qddot, which is exact for revolute/prismatic/helical single-DoF
+		// joints and a first-order approximation for the 3/6-DoF joints.
+		omegaParent := parentVel.Angular
+		vel[i] = Twist{
+			Angular: parentVel.Angular.Add(rotateTwist(frames[i], jt.Angular)),
+			Linear:  parentVel.Linear.Add(rotateTwist(frames[i], jt.Linear)),
+		}
+		acc[i] = Twist{
+			Angular: parentAcc.Angular.Add(rotateTwist(frames[i], jtAcc.Angular)),
+			Linear: parentAcc.Linear.
+				Add(rotateTwist(frames[i], jtAcc.Linear)).
+				Add(omegaParent.Cross(rotateTwist(frames[i], jt.Linear))),
+		}
+	}
+
+	// Backward pass: net wrench (force, torque) each body must receive at
+	// its own center of mass to realize its acceleration, then children's
+	// wrenches are added onto their parent (reaction forces transmitted
+	// through the joint) before we read off generalized forces.
+	force := make([]pga.Vec3, n)
+	torque := make([]pga.Vec3, n)
+	for i := n - 1; i >= 0; i-- {
+		l := t.Links[i]
+		comWorld := world[i].ActPoint(l.Body.Com)
+		comAccLinear := acc[i].Linear.Add(acc[i].Angular.Cross(comWorld)).
+			Add(vel[i].Angular.Cross(vel[i].Angular.Cross(comWorld)))
+
+		R := world[i].RotationMatrix()
+		Iworld := l.Body.InertiaTensor.Conjugate(R)
+		angularMomentumDot := Iworld.MulVec3(acc[i].Angular).
+			Add(vel[i].Angular.Cross(Iworld.MulVec3(vel[i].Angular)))
+
+		force[i] = comAccLinear.Scale(l.Body.Mass)
+		torque[i] = angularMomentumDot
+
+		for j := range t.Links {
+			if t.Links[j].Parent == i {
+				force[i] = force[i].Add(force[j])
+				childWorld := world[j].ActPoint(pga.V(0, 0, 0))
+				torque[i] = torque[i].Add(torque[j]).Add(force[j].Cross(childWorld.Sub(comWorld)))
+			}
+		}
+
+		// torque[i] is referenced to comWorld so far, but the projection
+		// below reads it off against Jacobian columns evaluated at the
+		// joint origin (world[i]'s own origin, not its COM); fold in the
+		// moment of the link's total force about that offset so the wrench
+		// is referenced consistently with the projection.
+		jointOrigin := world[i].ActPoint(pga.V(0, 0, 0))
+		torque[i] = torque[i].Add(force[i].Cross(comWorld.Sub(jointOrigin)))
+	}
+
+	tau := make([]float64, t.NumDoF())
+	for i, l := range t.Links {
+		lin, ang := l.JointToParent.JacobianColumns(frames[i], world[i].ActPoint(pga.V(0, 0, 0)))
+		for k := 0; k < l.JointToParent.DoF(); k++ {
+			tau[off[i]+k] = lin[k].Dot(force[i]) + ang[k].Dot(torque[i])
+		}
+	}
+	return tau
+}
+
+// rotateTwist rotates a vector expressed in a joint's attachment frame into
+// world coordinates, leaving translation out of it (it's a direction, not a
+// point).
+func rotateTwist(frame pga.Motor, v pga.Vec3) pga.Vec3 {
+	return frame.ActDir(v)
+}
@@ -0,0 +1,70 @@
+package multibody
+
+import (
+	"math"
+	"testing"
+
+	"goikga/pga"
+)
+
+// TestInverseDynamicsGravityCompensationSingleLink checks that a single
+// revolute pendulum, held static against gravity, reports the analytic
+// holding torque m*g*L about its joint.
+func TestInverseDynamicsGravityCompensationSingleLink(t *testing.T) {
+	const mass, lever, g = 1.0, 0.2, 9.81
+	tree := Tree{Links: []Link{
+		{
+			Parent:        -1,
+			JointToParent: RevoluteJoint{Axis: pga.V(0, 1, 0), Point: pga.V(0, 0, 0)},
+			RestMotor:     pga.Identity(),
+			Body:          Body{Mass: mass, Com: pga.V(lever, 0, 0)},
+		},
+	}}
+
+	q := []float64{0}
+	zero := []float64{0}
+	gravity := pga.V(0, 0, -g)
+
+	tau := tree.InverseDynamics(q, zero, zero, gravity)
+
+	want := mass * g * lever
+	if math.Abs(tau[0]-want) > 1e-9 {
+		t.Fatalf("InverseDynamics static torque = %v, want %v (m*g*L)", tau[0], want)
+	}
+}
+
+// TestInverseDynamicsGravityCompensationChain checks a two-link chain held
+// static against gravity: each joint's holding torque must equal the
+// analytic sum of m*g*L over every link whose weight it carries.
+func TestInverseDynamicsGravityCompensationChain(t *testing.T) {
+	const mass0, com0, mass1, com1, kneeOffset, g = 2.0, 0.3, 1.5, 0.4, 1.0, 9.81
+	tree := Tree{Links: []Link{
+		{
+			Parent:        -1,
+			JointToParent: RevoluteJoint{Axis: pga.V(0, 1, 0), Point: pga.V(0, 0, 0)},
+			RestMotor:     pga.Identity(),
+			Body:          Body{Mass: mass0, Com: pga.V(com0, 0, 0)},
+		},
+		{
+			Parent:        0,
+			JointToParent: RevoluteJoint{Axis: pga.V(0, 1, 0), Point: pga.V(0, 0, 0)},
+			RestMotor:     pga.Translator(pga.V(kneeOffset, 0, 0)),
+			Body:          Body{Mass: mass1, Com: pga.V(com1, 0, 0)},
+		},
+	}}
+
+	q := []float64{0, 0}
+	zero := []float64{0, 0}
+	gravity := pga.V(0, 0, -g)
+
+	tau := tree.InverseDynamics(q, zero, zero, gravity)
+
+	wantKnee := mass1 * g * com1
+	wantHip := mass0*g*com0 + mass1*g*(kneeOffset+com1)
+	if math.Abs(tau[1]-wantKnee) > 1e-9 {
+		t.Fatalf("knee torque = %v, want %v (m1*g*com1)", tau[1], wantKnee)
+	}
+	if math.Abs(tau[0]-wantHip) > 1e-9 {
+		t.Fatalf("hip torque = %v, want %v (m0*g*com0 + m1*g*(kneeOffset+com1))", tau[0], wantHip)
+	}
+}
@@ -0,0 +1,104 @@
+package batch
+
+import (
+	"math/rand"
+	"testing"
+
+	"goikga/pga"
+)
+
+// randMotor returns a unit-norm motor with a random rotation and
+// translation, for benchmark data that exercises the general (non-identity)
+// path of both the scalar and batch implementations.
+func randMotor(r *rand.Rand) pga.Motor {
+	axis := pga.V(r.Float64()-0.5, r.Float64()-0.5, r.Float64()-0.5)
+	theta := r.Float64() * 2
+	t := pga.V(r.Float64()-0.5, r.Float64()-0.5, r.Float64()-0.5)
+	return pga.Translator(t).Mul(pga.FromAxisAngle(axis, theta))
+}
+
+func randPoint(r *rand.Rand) pga.Vec3 {
+	return pga.V(r.Float64()-0.5, r.Float64()-0.5, r.Float64()-0.5)
+}
+
+// benchmarkActPoints compares ActPoints against the equivalent scalar
+// Motor.ActPoint loop at n lanes, shaped after the two workloads batch.go's
+// package doc calls out: a 100k-vertex skinning pass (one motor per vertex,
+// already blended) and a GA population's per-generation forward kinematics
+// (thousands of independent small chains evaluated per generation).
+func benchmarkActPoints(b *testing.B, n int) {
+	r := rand.New(rand.NewSource(1))
+	motors := NewMotors(n)
+	points := NewPoints(n)
+	for i := 0; i < n; i++ {
+		motors.Set(i, randMotor(r))
+		points.Set(i, randPoint(r))
+	}
+	out := NewPoints(n)
+
+	b.Run("scalar", func(b *testing.B) {
+		sm := make([]pga.Motor, n)
+		sp := make([]pga.Vec3, n)
+		for i := 0; i < n; i++ {
+			sm[i] = motors.At(i)
+			sp[i] = points.At(i)
+		}
+		so := make([]pga.Vec3, n)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for j := range sp {
+				so[j] = sm[j].ActPoint(sp[j])
+			}
+		}
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			ActPoints(motors, points, out)
+		}
+	})
+}
+
+func BenchmarkActPoints100kVertexSkinning(b *testing.B) { benchmarkActPoints(b, 100_000) }
+func BenchmarkActPointsGAInnerLoop(b *testing.B)        { benchmarkActPoints(b, 256) }
+
+// benchmarkMulPairwise compares MulPairwise against the equivalent scalar
+// Motor.Mul loop, the other per-lane hot path batch.go targets (composing a
+// batch of local joint motors into world motors).
+func benchmarkMulPairwise(b *testing.B, n int) {
+	r := rand.New(rand.NewSource(2))
+	a := NewMotors(n)
+	c := NewMotors(n)
+	for i := 0; i < n; i++ {
+		a.Set(i, randMotor(r))
+		c.Set(i, randMotor(r))
+	}
+	out := NewMotors(n)
+
+	b.Run("scalar", func(b *testing.B) {
+		sa := make([]pga.Motor, n)
+		sc := make([]pga.Motor, n)
+		for i := 0; i < n; i++ {
+			sa[i] = a.At(i)
+			sc[i] = c.At(i)
+		}
+		so := make([]pga.Motor, n)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for j := range sa {
+				so[j] = sa[j].Mul(sc[j])
+			}
+		}
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			MulPairwise(a, c, out)
+		}
+	})
+}
+
+func BenchmarkMulPairwise100kVertexSkinning(b *testing.B) { benchmarkMulPairwise(b, 100_000) }
+func BenchmarkMulPairwiseGAInnerLoop(b *testing.B)        { benchmarkMulPairwise(b, 256) }
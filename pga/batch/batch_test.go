@@ -0,0 +1,70 @@
+package batch
+
+import (
+	"math"
+	"testing"
+
+	"goikga/pga"
+)
+
+func TestActPointsMatchesScalar(t *testing.T) {
+	m := pga.Translator(pga.V(1, 2, 3)).Mul(pga.FromAxisAngle(pga.V(0, 1, 0), 0.8))
+	motors := NewMotors(3)
+	points := NewPoints(3)
+	pts := []pga.Vec3{pga.V(1, 0, 0), pga.V(0, 1, 0), pga.V(-2, 3, 1)}
+	for i, p := range pts {
+		motors.Set(i, m)
+		points.Set(i, p)
+	}
+	out := NewPoints(3)
+	ActPoints(motors, points, out)
+
+	for i, p := range pts {
+		want := m.ActPoint(p)
+		got := out.At(i)
+		if got.Sub(want).Norm() > 1e-9 {
+			t.Fatalf("lane %d: ActPoints = %v, want %v (Motor.ActPoint)", i, got, want)
+		}
+	}
+}
+
+func TestMulPairwiseMatchesScalar(t *testing.T) {
+	a := pga.Translator(pga.V(1, 0, 0)).Mul(pga.FromAxisAngle(pga.V(0, 0, 1), 0.3))
+	b := pga.Translator(pga.V(0, 2, 0)).Mul(pga.FromAxisAngle(pga.V(1, 0, 0), -0.5))
+
+	motorsA := NewMotors(1)
+	motorsB := NewMotors(1)
+	motorsA.Set(0, a)
+	motorsB.Set(0, b)
+	out := NewMotors(1)
+	MulPairwise(motorsA, motorsB, out)
+
+	want := a.Mul(b)
+	got := out.At(0)
+	wr, wd := want.DQParts()
+	gr, gd := got.DQParts()
+	for i := range wr {
+		if math.Abs(wr[i]-gr[i]) > 1e-9 || math.Abs(wd[i]-gd[i]) > 1e-9 {
+			t.Fatalf("MulPairwise = %+v, want %+v (Motor.Mul)", got, want)
+		}
+	}
+}
+
+func TestActPointsBroadcastMatchesScalar(t *testing.T) {
+	m := pga.Translator(pga.V(0, 0, 5)).Mul(pga.FromAxisAngle(pga.V(0, 1, 0), 1.2))
+	pts := []pga.Vec3{pga.V(1, 0, 0), pga.V(2, -1, 3)}
+	points := NewPoints(len(pts))
+	for i, p := range pts {
+		points.Set(i, p)
+	}
+	out := NewPoints(len(pts))
+	ActPointsBroadcast(m, points, out)
+
+	for i, p := range pts {
+		want := m.ActPoint(p)
+		got := out.At(i)
+		if got.Sub(want).Norm() > 1e-9 {
+			t.Fatalf("lane %d: ActPointsBroadcast = %v, want %v", i, got, want)
+		}
+	}
+}
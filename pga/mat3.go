@@ -0,0 +1,67 @@
+package pga
+
+// Mat3 is a plain 3x3 matrix, used for inertia tensors where a multivector
+// or dual-quaternion representation doesn't buy anything: row-major,
+// M[i][j] is row i, column j.
+type Mat3 struct {
+	M [3][3]float64
+}
+
+// Diag3 builds a diagonal Mat3, the common case for a body's inertia tensor
+// expressed in its own principal-axis frame.
+func Diag3(ixx, iyy, izz float64) Mat3 {
+	return Mat3{M: [3][3]float64{
+		{ixx, 0, 0},
+		{0, iyy, 0},
+		{0, 0, izz},
+	}}
+}
+
+func (a Mat3) MulVec3(v Vec3) Vec3 {
+	return Vec3{
+		X: a.M[0][0]*v.X + a.M[0][1]*v.Y + a.M[0][2]*v.Z,
+		Y: a.M[1][0]*v.X + a.M[1][1]*v.Y + a.M[1][2]*v.Z,
+		Z: a.M[2][0]*v.X + a.M[2][1]*v.Y + a.M[2][2]*v.Z,
+	}
+}
+
+func (a Mat3) Mul(b Mat3) Mat3 {
+	var out Mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			s := 0.0
+			for k := 0; k < 3; k++ {
+				s += a.M[i][k] * b.M[k][j]
+			}
+			out.M[i][j] = s
+		}
+	}
+	return out
+}
+
+func (a Mat3) Transpose() Mat3 {
+	var out Mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			out.M[i][j] = a.M[j][i]
+		}
+	}
+	return out
+}
+
+// RotationMatrix returns the 3x3 rotation matrix equivalent to m's rotation
+// part (its translation part is ignored).
+func (m Motor) RotationMatrix() Mat3 {
+	w, x, y, z := m.r.w, m.r.x, m.r.y, m.r.z
+	return Mat3{M: [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)},
+		{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)},
+		{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)},
+	}}
+}
+
+// Conjugate returns r * a * r^T, i.e. a transformed into the frame rotated
+// by r (the standard way to carry an inertia tensor between frames).
+func (a Mat3) Conjugate(r Mat3) Mat3 {
+	return r.Mul(a).Mul(r.Transpose())
+}
@@ -5,6 +5,13 @@ package pga
 // We expose only what we need: constructors for rotation/translation/screw, composition,
 // action on points, and inversion.
 //
+// This is a thin facade over the full multivector core in multivector.go: a
+// dual quaternion's 8 components are exactly the grade-{0,2,4} part of an
+// R(3,0,1) motor (scalar + rotation bivector e23/e31/e12 + translation
+// bivector e01/e02/e03 + pseudoscalar e0123), so existing FK/IK code built on
+// Motor keeps working unchanged while Plane/Line/Point/Meet/Join in
+// multivector.go give access to the rest of the algebra.
+//
 // References:
 // - Kavan et al., "Skinning with Dual Quaternions"
 // - Dorst, "Geometric Algebra for Computer Science" (motors / bivector exponentials)
@@ -116,3 +123,44 @@ func (a Motor) ActDir(v Vec3) Vec3 {
 	rp := a.r.Mul(pure(v)).Mul(a.r.Conj())
 	return Vec3{rp.x, rp.y, rp.z}
 }
+
+// MV returns the grade-{0,2,4} multivector equivalent to a, for use with the
+// Meet/Join/Distance/Angle machinery in multivector.go. The e02 and e0123
+// coefficients carry a negative sign relative to the dual quaternion's raw
+// (w,x,y,z) components -- required for Geometric to reproduce Mul, and for
+// the sandwich m.Geometric(Point(p)).Geometric(m.Reverse()) to reproduce
+// ActPoint; verified by round-trip in multivector_test.go.
+func (a Motor) MV() MV {
+	var m MV
+	m[0] = a.r.w
+	m[Index(2, 3)] = a.r.x
+	m[Index(3, 1)] = a.r.y
+	m[Index(1, 2)] = a.r.z
+	m[Index(0, 1)] = a.d.x
+	m[Index(0, 2)] = -a.d.y
+	m[Index(0, 3)] = a.d.z
+	m[Index(0, 1, 2, 3)] = -a.d.w
+	return m
+}
+
+// FromMV recovers the Motor equivalent to a grade-{0,2,4} multivector
+// produced by MV or MV.Exp.
+func FromMV(m MV) Motor {
+	return Motor{
+		r: q(m[0], m[Index(2, 3)], m[Index(3, 1)], m[Index(1, 2)]),
+		d: q(-m[Index(0, 1, 2, 3)], m[Index(0, 1)], -m[Index(0, 2)], m[Index(0, 3)]),
+	}
+}
+
+// DQParts returns the raw dual-quaternion components (r, d) backing the
+// motor, for packages (e.g. skin) that need to blend several motors'
+// components directly rather than through Mul/ActPoint.
+func (a Motor) DQParts() (r, d [4]float64) {
+	return [4]float64{a.r.w, a.r.x, a.r.y, a.r.z}, [4]float64{a.d.w, a.d.x, a.d.y, a.d.z}
+}
+
+// MotorFromDQParts builds a Motor from raw dual-quaternion components, as
+// produced by DQParts.
+func MotorFromDQParts(r, d [4]float64) Motor {
+	return Motor{r: q(r[0], r[1], r[2], r[3]), d: q(d[0], d[1], d[2], d[3])}
+}
@@ -0,0 +1,298 @@
+package pga
+
+import "math"
+
+// MV is a general multivector in R(3,0,1), the projective geometric algebra
+// used to model points, lines, planes and rigid motions in 3D homogeneous
+// space. The algebra has four generators e0,e1,e2,e3 with e0^2=0 (the
+// degenerate/ideal direction) and e1^2=e2^2=e3^2=1, giving 2^4=16 basis
+// blades. Coefficients are stored indexed by a bitmask over the generators
+// (bit i set means e_i participates), which keeps the geometric/wedge/
+// regressive products mechanical instead of hand-tabulated:
+//
+//	bit 0 -> e0   bit 1 -> e1   bit 2 -> e2   bit 3 -> e3
+//
+// The blade names used in comments/constructors follow the usual PGA
+// convention (1, e0, e1, e2, e3, e01, e02, e03, e12, e31, e23, e021, e013,
+// e032, e123, e0123) even though internally everything is kept in
+// lexicographic bitmask order. Index maps generator indices to that
+// bitmask and applies no reordering sign, so e.g. Index(3,1) addresses the
+// stored e13 coefficient.
+type MV [16]float64
+
+// metric squares of e0,e1,e2,e3.
+var metric = [4]float64{0, 1, 1, 1}
+
+// Index returns the bitmask for the lexicographically-ordered product of the
+// given generator indices (each in 0..3, in any order; no sign is applied
+// for the reordering), e.g. Index(1,2) and Index(2,1) are both the bitmask
+// for e12.
+func Index(gens ...int) int {
+	m := 0
+	for _, g := range gens {
+		m |= 1 << uint(g)
+	}
+	return m
+}
+
+// bladeProd multiplies two basis blades given as bitmasks, returning the
+// resulting bitmask and the scalar factor (which may be 0 if a repeated
+// degenerate generator e0 appears, or ±1 otherwise).
+func bladeProd(a, b int) (int, float64) {
+	sign := 1.0
+	// Move each generator of b past the generators of a that are "above" it,
+	// counting transpositions, then contract any repeated generators using
+	// the metric.
+	for i := 0; i < 4; i++ {
+		if b&(1<<uint(i)) == 0 {
+			continue
+		}
+		// count bits of `a` above position i (already-placed generators of a
+		// that this generator of b must hop over).
+		higher := a >> uint(i+1)
+		swaps := popcount(higher)
+		if swaps%2 == 1 {
+			sign = -sign
+		}
+		if a&(1<<uint(i)) != 0 {
+			// contraction: e_i * e_i
+			sign *= metric[i]
+			a &^= 1 << uint(i)
+		} else {
+			a |= 1 << uint(i)
+		}
+	}
+	return a, sign
+}
+
+func popcount(x int) int {
+	n := 0
+	for x != 0 {
+		n += x & 1
+		x >>= 1
+	}
+	return n
+}
+
+// Geometric returns the geometric product a*b.
+func (a MV) Geometric(b MV) MV {
+	var out MV
+	for i := 0; i < 16; i++ {
+		if a[i] == 0 {
+			continue
+		}
+		for j := 0; j < 16; j++ {
+			if b[j] == 0 {
+				continue
+			}
+			bits, sign := bladeProd(i, j)
+			out[bits] += sign * a[i] * b[j]
+		}
+	}
+	return out
+}
+
+// Wedge returns the outer product a^b (the join of disjoint blades).
+func (a MV) Wedge(b MV) MV {
+	var out MV
+	for i := 0; i < 16; i++ {
+		if a[i] == 0 {
+			continue
+		}
+		for j := 0; j < 16; j++ {
+			if b[j] == 0 || i&j != 0 {
+				continue
+			}
+			bits, sign := bladeProd(i, j)
+			out[bits] += sign * a[i] * b[j]
+		}
+	}
+	return out
+}
+
+// Dual returns the Poincare dual (complement) of a: for every blade e_A it
+// returns the coefficient on the complementary blade e_A* such that
+// e_A ^ e_A* = +e0123. This is well-defined even though e0123 is not
+// invertible under the geometric product (the usual obstruction to a
+// metric-dual in degenerate algebras).
+func (a MV) Dual() MV {
+	var out MV
+	for i := 0; i < 16; i++ {
+		if a[i] == 0 {
+			continue
+		}
+		ci := 15 ^ i
+		_, sign := bladeProd(i, ci)
+		out[ci] += a[i] * sign
+	}
+	return out
+}
+
+// Reverse returns ~a, reversing the order of vectors in each blade (grade k
+// picks up a sign of (-1)^(k(k-1)/2)).
+func (a MV) Reverse() MV {
+	var out MV
+	for i := 0; i < 16; i++ {
+		k := popcount(i)
+		sign := 1.0
+		if (k*(k-1)/2)%2 == 1 {
+			sign = -1
+		}
+		out[i] = a[i] * sign
+	}
+	return out
+}
+
+// Grade returns the projection of a onto blades of the given grade (0..4).
+func (a MV) Grade(k int) MV {
+	var out MV
+	for i := 0; i < 16; i++ {
+		if popcount(i) == k {
+			out[i] = a[i]
+		}
+	}
+	return out
+}
+
+// Join is the regressive product a v b = !(!a ^ !b): the smallest subspace
+// containing both a and b (e.g. the line through two points, or the plane
+// through a point and a line). With this algebra's convention of points as
+// grade-3 and planes as grade-1, building up from points necessarily goes
+// through the regressive product, since the wedge of two grade-3 points
+// would land on grade 6 (always zero).
+func Join(a, b MV) MV { return a.Dual().Wedge(b.Dual()).Dual() }
+
+// Meet is the wedge product a ^ b: the intersection of the subspaces
+// spanned by a and b (e.g. the point where two planes and a third plane
+// cross, or the line where two planes cross).
+func Meet(a, b MV) MV { return a.Wedge(b) }
+
+// Plane constructs the grade-1 plane a*e1 + b*e2 + c*e3 + d*e0 (points X on
+// the plane satisfy X . Plane = 0 in the usual PGA incidence sense).
+func Plane(a, b, c, d float64) MV {
+	var p MV
+	p[Index(1)] = a
+	p[Index(2)] = b
+	p[Index(3)] = c
+	p[Index(0)] = d
+	return p
+}
+
+// Line constructs the grade-2 line through point p with direction d, as the
+// join of p and the ideal point along d.
+func Line(p, d Vec3) MV {
+	return Join(Point(p.X, p.Y, p.Z), idealPoint(d)).Grade(2)
+}
+
+// idealPoint is the point at infinity in direction d (grade-3, zero e123
+// weight).
+func idealPoint(d Vec3) MV {
+	var pt MV
+	pt[Index(0, 2, 3)] = d.X
+	pt[Index(0, 3, 1)] = d.Y
+	pt[Index(0, 1, 2)] = d.Z
+	return pt
+}
+
+// Point constructs the grade-3 Euclidean point (x,y,z), normalized so the
+// e123 coefficient is 1.
+func Point(x, y, z float64) MV {
+	var pt MV
+	pt[Index(1, 2, 3)] = 1
+	pt[Index(0, 2, 3)] = x
+	pt[Index(0, 3, 1)] = y
+	pt[Index(0, 1, 2)] = z
+	return pt
+}
+
+// Vec3 extracts the Euclidean coordinates of a grade-3 point produced by
+// Point (or any point-shaped result of Meet/Join), dividing through by the
+// e123 weight.
+func (a MV) Vec3() Vec3 {
+	w := a[Index(1, 2, 3)]
+	if w == 0 {
+		return V(0, 0, 0)
+	}
+	return V(a[Index(0, 2, 3)]/w, a[Index(0, 3, 1)]/w, a[Index(0, 1, 2)]/w)
+}
+
+// Norm is the square root of the sum of squares of a's components that
+// survive the metric (i.e. |a . ~a|, read off the scalar grade).
+func (a MV) Norm() float64 {
+	prod := a.Geometric(a.Reverse())
+	return math.Sqrt(math.Abs(prod[0]))
+}
+
+// Normalized returns a scaled so that Norm() == 1.
+func (a MV) Normalized() MV {
+	n := a.Norm()
+	if n == 0 {
+		return a
+	}
+	var out MV
+	for i := range a {
+		out[i] = a[i] / n
+	}
+	return out
+}
+
+// Distance returns the Euclidean distance between two normalized points, or
+// the point-to-plane distance when one argument is a plane, read off the
+// norm of their join.
+func Distance(a, b MV) float64 {
+	an, bn := a.Normalized(), b.Normalized()
+	return Join(an, bn).Norm()
+}
+
+// Angle returns the angle in radians between two normalized planes or lines,
+// read off the inner (scalar) part of their geometric product.
+func Angle(a, b MV) float64 {
+	an, bn := a.Normalized(), b.Normalized()
+	prod := an.Geometric(bn.Reverse())
+	cos := prod[0]
+	if cos > 1 {
+		cos = 1
+	} else if cos < -1 {
+		cos = -1
+	}
+	return math.Acos(cos)
+}
+
+// Exp returns the motor exp(B) for a bivector B (grades 0/2/4 only are
+// meaningful on input; see Log/Exp in screw.go for the dual-quaternion-backed
+// equivalent used by the rest of the package).
+func (b MV) Exp() MV {
+	// Split into the "rotational" part (e23,e31,e12) and the "translational"
+	// part (e01,e02,e03); the e0123 part of a simple bivector is always 0.
+	rx, ry, rz := b[Index(2, 3)], b[Index(3, 1)], b[Index(1, 2)]
+	theta := math.Sqrt(rx*rx + ry*ry + rz*rz)
+
+	var out MV
+	out[0] = 1
+	tx, ty, tz := b[Index(0, 1)], b[Index(0, 2)], b[Index(0, 3)]
+	if theta < 1e-12 {
+		// Pure translation (or zero): exp(B) = 1 + B.
+		out[Index(2, 3)] = rx
+		out[Index(3, 1)] = ry
+		out[Index(1, 2)] = rz
+		out[Index(0, 1)] = tx
+		out[Index(0, 2)] = ty
+		out[Index(0, 3)] = tz
+		return out
+	}
+	c, s := math.Cos(theta), math.Sin(theta)
+	sinc := s / theta
+	out[0] = c
+	out[Index(2, 3)] = sinc * rx
+	out[Index(3, 1)] = sinc * ry
+	out[Index(1, 2)] = sinc * rz
+	// Translational bivector components carry through sinc as well; the
+	// e0123 (screw/pitch coupling) term needs the derivative of sinc.
+	dsinc := (c - sinc) / theta
+	dot := (rx*tx + ry*ty + rz*tz) / theta
+	out[Index(0, 1)] = sinc*tx + dsinc*dot*rx/theta
+	out[Index(0, 2)] = sinc*ty + dsinc*dot*ry/theta
+	out[Index(0, 3)] = sinc*tz + dsinc*dot*rz/theta
+	out[Index(0, 1, 2, 3)] = dot * (c/theta - sinc/theta)
+	return out
+}
@@ -0,0 +1,66 @@
+package pga
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistancePoints(t *testing.T) {
+	got := Distance(Point(0, 0, 0), Point(3, 4, 0))
+	if math.Abs(got-5) > 1e-9 {
+		t.Fatalf("Distance((0,0,0),(3,4,0)) = %v, want 5", got)
+	}
+}
+
+func TestMeetOfPlanesIsNonzero(t *testing.T) {
+	// Two non-parallel planes (the XZ and YZ planes, y=0 and x=0) meet in
+	// the Z axis -- a nonzero line, not the zero multivector.
+	planeY0 := Plane(0, 1, 0, 0)
+	planeX0 := Plane(1, 0, 0, 0)
+	line := Meet(planeY0, planeX0)
+	if line.Norm() == 0 {
+		t.Fatalf("Meet of two non-parallel planes is zero, want a line")
+	}
+}
+
+func TestJoinOfPointsIsLine(t *testing.T) {
+	p1 := Point(0, 0, 0)
+	p2 := Point(1, 0, 0)
+	line := Join(p1, p2)
+	if line.Norm() == 0 {
+		t.Fatalf("Join of two distinct points is zero, want a line")
+	}
+}
+
+func TestMotorMVMatchesMul(t *testing.T) {
+	a := Screw(V(1, 2, 3), V(0, 1, 0), 0.7, 0.2)
+	b := Screw(V(-1, 0, 2), V(1, 0, 0), -1.1, 0)
+
+	want := a.Mul(b).MV()
+	got := a.MV().Geometric(b.MV())
+	for i := range want {
+		if math.Abs(want[i]-got[i]) > 1e-9 {
+			t.Fatalf("MV blade %d: a.Mul(b).MV()=%v, a.MV().Geometric(b.MV())=%v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestMotorMVRoundTrip(t *testing.T) {
+	a := Screw(V(1, 2, 3), V(0, 1, 0), 0.7, 0.2)
+	back := FromMV(a.MV())
+	if back.r != a.r || back.d != a.d {
+		t.Fatalf("FromMV(a.MV()) = %+v, want %+v", back, a)
+	}
+}
+
+func TestMotorMVSandwichMatchesActPoint(t *testing.T) {
+	m := Screw(V(1, 2, 3), V(0, 1, 0), 0.7, 0.2)
+	p := V(2, -1, 4)
+
+	want := m.ActPoint(p)
+	got := m.MV().Geometric(Point(p.X, p.Y, p.Z)).Geometric(m.MV().Reverse()).Vec3()
+
+	if want.Sub(got).Norm() > 1e-9 {
+		t.Fatalf("sandwich gives %v, ActPoint gives %v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package pga
+
+import "math"
+
+// Bivector is the logarithm of a Motor: a screw axis direction W (whose
+// norm is the half-angle of rotation) together with its dual part Wd
+// (translation/pitch along the axis), following the usual dual-number
+// extension of the quaternion exponential/logarithm to dual quaternions.
+type Bivector struct {
+	W, Wd Vec3
+}
+
+// Scale multiplies both parts of b by t, as used by ScLERP to take a
+// fractional step along a screw axis.
+func (b Bivector) Scale(t float64) Bivector {
+	return Bivector{W: b.W.Scale(t), Wd: b.Wd.Scale(t)}
+}
+
+// Add adds two bivectors componentwise.
+func (b Bivector) Add(c Bivector) Bivector {
+	return Bivector{W: b.W.Add(c.W), Wd: b.Wd.Add(c.Wd)}
+}
+
+// Log returns the bivector logarithm of a, i.e. the screw axis scaled by the
+// half rotation angle (plus its dual translation/pitch part) that Exp maps
+// back to a.
+func (a Motor) Log() Bivector {
+	rv := V(a.r.x, a.r.y, a.r.z)
+	rvNorm := rv.Norm()
+	dv := V(a.d.x, a.d.y, a.d.z)
+	if rvNorm < 1e-12 {
+		// No rotation: a pure translation motor, whose dual vector part is
+		// already the translation generator.
+		return Bivector{W: V(0, 0, 0), Wd: dv}
+	}
+	theta := math.Atan2(rvNorm, a.r.w)
+	n := rv.Scale(1 / rvNorm)
+	s, c := rvNorm, a.r.w
+	sinc := s / theta
+	phi := -a.d.w / s
+	wd := dv.Sub(n.Scale(phi * (c - sinc))).Scale(1 / sinc)
+	return Bivector{W: n.Scale(theta), Wd: wd}
+}
+
+// Exp returns the motor exp(b), inverting Log: Exp(Log(m)) == m for any
+// motor m (mod the usual 2*pi rotation ambiguity).
+func (b Bivector) Exp() Motor {
+	theta := b.W.Norm()
+	if theta < 1e-12 {
+		// exp of a pure translation generator (or the zero bivector).
+		return Motor{r: q(1, 0, 0, 0), d: q(0, b.Wd.X, b.Wd.Y, b.Wd.Z)}
+	}
+	n := b.W.Scale(1 / theta)
+	c, s := math.Cos(theta), math.Sin(theta)
+	sinc := s / theta
+	phi := b.W.Dot(b.Wd) / theta
+
+	dv := n.Scale(phi * (c - sinc)).Add(b.Wd.Scale(sinc))
+	r := q(c, s*n.X, s*n.Y, s*n.Z)
+	d := q(-phi*s, dv.X, dv.Y, dv.Z)
+	return Motor{r: r, d: d}
+}
+
+// ScLERP returns the constant-velocity screw interpolation between motors a
+// and b at parameter t (t=0 -> a, t=1 -> b), moving along a single screw
+// axis rather than interpolating components independently.
+func ScLERP(a, b Motor, t float64) Motor {
+	return a.Mul(a.Inv().Mul(b).Log().Scale(t).Exp())
+}
+
+// BlendN computes a weighted blend of motors by iteratively averaging in log
+// space around a running estimate and re-exponentiating (a Newton-style
+// dual-quaternion Karcher mean), converging in a handful of iterations for
+// the angular spreads typical of skinning/animation blending.
+func BlendN(motors []Motor, weights []float64) Motor {
+	if len(motors) == 0 {
+		return Identity()
+	}
+	wsum := 0.0
+	for _, w := range weights {
+		wsum += w
+	}
+	if wsum == 0 {
+		wsum = 1
+	}
+
+	m := motors[0]
+	for iter := 0; iter < 4; iter++ {
+		var avg Bivector
+		for i, mi := range motors {
+			d := m.Inv().Mul(mi).Log()
+			avg = avg.Add(d.Scale(weights[i] / wsum))
+		}
+		m = m.Mul(avg.Exp())
+		if avg.W.Norm()+avg.Wd.Norm() < 1e-10 {
+			break
+		}
+	}
+	return m
+}
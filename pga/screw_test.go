@@ -0,0 +1,91 @@
+package pga
+
+import "testing"
+
+// motorsClose reports whether a and b represent the same rigid motion,
+// allowing for the usual dual-quaternion antipodal ambiguity (q and -q
+// encode the same motor).
+func motorsClose(a, b Motor, tol float64) bool {
+	ar, ad := a.DQParts()
+	br, bd := b.DQParts()
+
+	dot := 0.0
+	for i := range ar {
+		dot += ar[i] * br[i]
+	}
+	sign := 1.0
+	if dot < 0 {
+		sign = -1
+	}
+
+	for i := range ar {
+		if abs(ar[i]-sign*br[i]) > tol {
+			return false
+		}
+	}
+	for i := range ad {
+		if abs(ad[i]-sign*bd[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestLogExpRoundTrip(t *testing.T) {
+	cases := []Motor{
+		Screw(V(1, 2, 3), V(0, 1, 0), 0.7, 0.2),
+		Screw(V(-1, 0, 2), V(1, 0, 0), -1.1, 0),
+		Translator(V(1, -2, 0.5)),
+		FromAxisAngle(V(0, 0, 1), 1.4),
+		Identity(),
+	}
+	for i, m := range cases {
+		back := m.Log().Exp()
+		if !motorsClose(m, back, 1e-9) {
+			t.Fatalf("case %d: Log().Exp() = %+v, want %+v", i, back, m)
+		}
+	}
+}
+
+func TestScLERPEndpoints(t *testing.T) {
+	a := Screw(V(1, 2, 3), V(0, 1, 0), 0.7, 0.2)
+	b := Screw(V(-1, 0, 2), V(1, 0, 0), -1.1, 0)
+
+	if got := ScLERP(a, b, 0); !motorsClose(got, a, 1e-9) {
+		t.Fatalf("ScLERP(a,b,0) = %+v, want a = %+v", got, a)
+	}
+	if got := ScLERP(a, b, 1); !motorsClose(got, b, 1e-9) {
+		t.Fatalf("ScLERP(a,b,1) = %+v, want b = %+v", got, b)
+	}
+}
+
+func TestScLERPMidpointActsOnPoint(t *testing.T) {
+	// At t=0.5 between identity and a pure 180-degree rotation about Z
+	// through the origin, ScLERP should sweep (1,0,0) to (0,1,0).
+	a := Identity()
+	b := FromAxisAngle(V(0, 0, 1), 3.141592653589793)
+	mid := ScLERP(a, b, 0.5)
+
+	got := mid.ActPoint(V(1, 0, 0))
+	want := V(0, 1, 0)
+	if got.Sub(want).Norm() > 1e-6 {
+		t.Fatalf("ScLERP midpoint rotated (1,0,0) to %v, want %v", got, want)
+	}
+}
+
+func TestBlendNAgreesWithEndpointsAtExtremeWeights(t *testing.T) {
+	a := Screw(V(1, 2, 3), V(0, 1, 0), 0.7, 0.2)
+	b := Screw(V(-1, 0, 2), V(1, 0, 0), -1.1, 0)
+
+	got := BlendN([]Motor{a, b}, []float64{1, 0})
+	if !motorsClose(got, a, 1e-9) {
+		t.Fatalf("BlendN with weight 1 on a = %+v, want a = %+v", got, a)
+	}
+}
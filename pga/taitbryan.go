@@ -0,0 +1,131 @@
+package pga
+
+import (
+	"errors"
+	"math"
+)
+
+// Gimbal is returned by Motor.TaitBryan when the middle-axis angle lands at
+// a singularity (sine of the middle angle is ±1 for Tait-Bryan orders, or
+// the middle angle is 0/pi for proper Euler orders): the outer two axes
+// have collapsed onto a single effective rotation, so only their sum/
+// difference is determined. In that case the returned c is always 0 and a
+// carries the combined angle.
+var Gimbal = errors.New("pga: gimbal lock, outer axes collapsed onto one angle")
+
+var axisVec = [3]Vec3{V(1, 0, 0), V(0, 1, 0), V(0, 0, 1)}
+
+func axisIndex(c byte) int {
+	switch c {
+	case 'X', 'x':
+		return 0
+	case 'Y', 'y':
+		return 1
+	case 'Z', 'z':
+		return 2
+	default:
+		panic("pga: invalid Tait-Bryan/Euler axis letter " + string(c))
+	}
+}
+
+func parseOrder(order string) [3]int {
+	if len(order) != 3 {
+		panic("pga: Tait-Bryan/Euler order must name exactly 3 axes, got " + order)
+	}
+	return [3]int{axisIndex(order[0]), axisIndex(order[1]), axisIndex(order[2])}
+}
+
+// permSign returns +1 if (i,j,k) is an even permutation of (0,1,2), -1 if
+// odd (i,j,k assumed pairwise distinct).
+func permSign(i, j, k int) float64 {
+	// There are only 6 permutations of 3 distinct elements; a tiny direct
+	// table is clearer than computing transposition parity generically.
+	switch [3]int{i, j, k} {
+	case [3]int{0, 1, 2}, [3]int{1, 2, 0}, [3]int{2, 0, 1}:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// FromTaitBryan builds the motor R_order[0](a) * R_order[1](b) * R_order[2](c)
+// for a 3-letter axis order such as "XYZ" (Tait-Bryan, all axes distinct) or
+// "ZYZ" (proper Euler, first and last axis the same) -- the 12 orderings
+// enumerated in mint-style rotation conventions.
+func FromTaitBryan(order string, a, b, c float64) Motor {
+	ax := parseOrder(order)
+	return FromAxisAngle(axisVec[ax[0]], a).
+		Mul(FromAxisAngle(axisVec[ax[1]], b)).
+		Mul(FromAxisAngle(axisVec[ax[2]], c))
+}
+
+// TaitBryan decomposes m's rotation into the three angles of the given
+// order, inverting FromTaitBryan. It returns Gimbal (with c set to 0) when
+// the decomposition hits the order's singularity.
+func (m Motor) TaitBryan(order string) (a, b, c float64, err error) {
+	ax := parseOrder(order)
+	i, j, k := ax[0], ax[1], ax[2]
+	R := m.RotationMatrix().M
+
+	if i != k {
+		return taitBryanDistinct(R, i, j, k)
+	}
+	return properEuler(R, i, j, k)
+}
+
+// taitBryanDistinct handles the 6 orders with three distinct axes (XYZ,
+// XZY, YXZ, YZX, ZXY, ZYX).
+func taitBryanDistinct(R [3][3]float64, i, j, k int) (a, b, c float64, err error) {
+	s := permSign(i, j, k)
+	bsin := s * R[i][k]
+	if bsin > 1 {
+		bsin = 1
+	} else if bsin < -1 {
+		bsin = -1
+	}
+	b = math.Asin(bsin)
+
+	const eps = 1e-9
+	if math.Abs(bsin) > 1-eps {
+		a = math.Atan2(s*R[k][j], R[j][j])
+		return a, b, 0, Gimbal
+	}
+	a = math.Atan2(-s*R[j][k], R[k][k])
+	c = math.Atan2(-s*R[i][j], R[i][i])
+	return a, b, c, nil
+}
+
+// properEuler handles the 6 orders with a repeated first/last axis (XYX,
+// XZX, YXY, YZY, ZXZ, ZYZ).
+func properEuler(R [3][3]float64, i, j, kw int) (a, b, c float64, err error) {
+	// kw passed in as the order's last axis, but for a proper Euler order
+	// that equals i; recover the genuinely distinct third axis instead.
+	third := 3 - i - j
+	s := permSign(j, i, third)
+
+	cosb := R[i][i]
+	if cosb > 1 {
+		cosb = 1
+	} else if cosb < -1 {
+		cosb = -1
+	}
+	b = math.Acos(cosb)
+
+	const eps = 1e-9
+	sinb := math.Sin(b)
+	if sinb < eps {
+		a = math.Atan2(-s*R[third][j], R[j][j])
+		return a, b, 0, Gimbal
+	}
+	a = math.Atan2(R[j][i], s*R[third][i])
+	c = math.Atan2(R[i][j], -s*R[i][third])
+	return a, b, c, nil
+}
+
+// OrientationError returns the rotation-vector (axis times angle) part of
+// Log(a.Inv().Mul(b)): a smooth 3-vector residual an IK/control loop can
+// drive towards zero, in place of differencing quaternions/matrices
+// directly.
+func OrientationError(a, b Motor) Vec3 {
+	return a.Inv().Mul(b).Log().W.Scale(2)
+}
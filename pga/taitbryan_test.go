@@ -0,0 +1,48 @@
+package pga
+
+import (
+	"math"
+	"testing"
+)
+
+var allOrders = []string{
+	"XYZ", "XZY", "YXZ", "YZX", "ZXY", "ZYX", // Tait-Bryan
+	"XYX", "XZX", "YXY", "YZY", "ZXZ", "ZYZ", // proper Euler
+}
+
+func TestTaitBryanRoundTrip(t *testing.T) {
+	a, b, c := 0.3, 0.5, -0.2 // away from any order's singularity
+	for _, order := range allOrders {
+		m := FromTaitBryan(order, a, b, c)
+		ga, gb, gc, err := m.TaitBryan(order)
+		if err != nil {
+			t.Fatalf("order %s: TaitBryan returned %v, want no error", order, err)
+		}
+		back := FromTaitBryan(order, ga, gb, gc)
+		if !motorsClose(m, back, 1e-9) {
+			t.Fatalf("order %s: got angles (%v,%v,%v), FromTaitBryan(them) = %+v, want %+v", order, ga, gb, gc, back, m)
+		}
+	}
+}
+
+func TestTaitBryanGimbalLock(t *testing.T) {
+	cases := []struct {
+		order string
+		b     float64
+	}{
+		{"XYZ", math.Pi / 2},
+		{"ZYX", -math.Pi / 2},
+		{"XYX", 0},
+		{"ZYZ", 0},
+	}
+	for _, tc := range cases {
+		m := FromTaitBryan(tc.order, 0.4, tc.b, -0.3)
+		_, _, c, err := m.TaitBryan(tc.order)
+		if err != Gimbal {
+			t.Fatalf("order %s at b=%v: TaitBryan returned err=%v, want Gimbal", tc.order, tc.b, err)
+		}
+		if c != 0 {
+			t.Fatalf("order %s at b=%v: c = %v, want 0 on gimbal lock", tc.order, tc.b, c)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package skin
+
+import (
+	"sort"
+
+	"goikga/pga"
+)
+
+// Animator samples one glTF animation's channels and produces a Pose at an
+// arbitrary time, interpolating according to each sampler's interpolation
+// mode (STEP, LINEAR, CUBICSPLINE).
+type Animator struct {
+	skel    *Skeleton
+	doc     *gltfDocument
+	anim    gltfAnimation
+	times   [][]float64   // per-sampler keyframe times
+	outputs [][][]float64 // per-sampler keyframe output vectors
+}
+
+// NewAnimator builds an Animator for animation index animIdx of doc, whose
+// channels must target nodes belonging to skel.
+func NewAnimator(skel *Skeleton, doc *gltfDocument, animIdx int) (*Animator, error) {
+	anim := doc.Animations[animIdx]
+	times := make([][]float64, len(anim.Samplers))
+	outputs := make([][][]float64, len(anim.Samplers))
+	for i, s := range anim.Samplers {
+		t, err := doc.readFloats(s.Input)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := doc.readFloats(s.Output)
+		if err != nil {
+			return nil, err
+		}
+		stride := len(raw) / len(t)
+		vecs := make([][]float64, len(t))
+		for k := range vecs {
+			vecs[k] = raw[k*stride : (k+1)*stride]
+		}
+		times[i] = t
+		outputs[i] = vecs
+	}
+	return &Animator{skel: skel, doc: doc, anim: anim, times: times, outputs: outputs}, nil
+}
+
+// Sample returns the skeleton pose at time t (seconds), defaulting any joint
+// not targeted by a channel to its rest-pose local motor.
+func (a *Animator) Sample(t float64) Pose {
+	pose := a.skel.RestPose()
+
+	// Per-joint accumulated translation/rotation, defaulting to rest.
+	translations := make(map[int]pga.Vec3)
+	rotations := make(map[int]pga.Motor)
+
+	for _, ch := range a.anim.Channels {
+		node := ch.Target.Node
+		ji, ok := jointForNode(a.doc, node)
+		if !ok {
+			continue
+		}
+		s := a.anim.Samplers[ch.Sampler]
+		switch ch.Target.Path {
+		case "translation":
+			v := sampleVec3(a.times[ch.Sampler], a.outputs[ch.Sampler], s.Interpolation, t)
+			translations[ji] = v
+		case "rotation":
+			rotations[ji] = sampleQuat(a.times[ch.Sampler], a.outputs[ch.Sampler], s.Interpolation, t)
+		}
+	}
+
+	for ji := range a.skel.Joints {
+		tr, hasT := translations[ji]
+		rm, hasR := rotations[ji]
+		if !hasT && !hasR {
+			continue
+		}
+		rest := pose[ji]
+		restT := rest.ActPoint(pga.V(0, 0, 0))
+		m := pga.Translator(restT.Neg()).Mul(rest) // rest rotation
+		if hasR {
+			m = rm
+		}
+		if !hasT {
+			tr = restT
+		}
+		pose[ji] = pga.Translator(tr).Mul(m)
+	}
+	return pose
+}
+
+func jointForNode(doc *gltfDocument, node int) (int, bool) {
+	// Re-derive node->joint from the single skin loaded by LoadGLTF; callers
+	// only build an Animator from a Skeleton produced that way, so joint
+	// order matches doc.Skins[0].Joints.
+	for ji, n := range doc.Skins[0].Joints {
+		if n == node {
+			return ji, true
+		}
+	}
+	return 0, false
+}
+
+func sampleVec3(times []float64, outputs [][]float64, interp string, t float64) pga.Vec3 {
+	i, frac := keyframeIndex(times, t)
+	switch interp {
+	case "STEP":
+		o := outputs[i]
+		return pga.V(o[0], o[1], o[2])
+	case "CUBICSPLINE":
+		// outputs are (in-tangent, value, out-tangent) triples per keyframe;
+		// the value components are interpolated linearly and the tangents
+		// are not used.
+		j := i
+		if j+1 >= len(outputs) {
+			j = len(outputs) - 2
+			if j < 0 {
+				j = 0
+			}
+			frac = 1
+		}
+		v0 := outputs[j][3:6]
+		v1 := outputs[j+1][3:6]
+		return pga.V(lerp(v0[0], v1[0], frac), lerp(v0[1], v1[1], frac), lerp(v0[2], v1[2], frac))
+	default: // LINEAR
+		j := i
+		if j+1 >= len(outputs) {
+			return pga.V(outputs[j][0], outputs[j][1], outputs[j][2])
+		}
+		a, b := outputs[j], outputs[j+1]
+		return pga.V(lerp(a[0], b[0], frac), lerp(a[1], b[1], frac), lerp(a[2], b[2], frac))
+	}
+}
+
+// sampleQuat interpolates a rotation channel, returning it as a pure-rotation
+// Motor. LINEAR/CUBICSPLINE segments are blended with pga.ScLERP (screw
+// interpolation along a single constant-velocity axis) rather than a
+// component-wise quaternion slerp, consistent with how the rest of the
+// package moves between motors.
+func sampleQuat(times []float64, outputs [][]float64, interp string, t float64) pga.Motor {
+	i, frac := keyframeIndex(times, t)
+	switch interp {
+	case "STEP":
+		return quatMotor(outputs[i][0:4])
+	case "CUBICSPLINE":
+		j := i
+		if j+1 >= len(outputs) {
+			j = len(outputs) - 2
+			if j < 0 {
+				j = 0
+			}
+			frac = 1
+		}
+		return blendQuat(outputs[j][4:8], outputs[j+1][4:8], frac)
+	default: // LINEAR
+		j := i
+		if j+1 >= len(outputs) {
+			return quatMotor(outputs[j][0:4])
+		}
+		return blendQuat(outputs[j], outputs[j+1], frac)
+	}
+}
+
+// quatMotor builds a pure-rotation Motor from a glTF quaternion given as
+// (x,y,z,w).
+func quatMotor(q []float64) pga.Motor {
+	return pga.MotorFromDQParts([4]float64{q[3], q[0], q[1], q[2]}, [4]float64{0, 0, 0, 0})
+}
+
+// blendQuat ScLERPs between two glTF (x,y,z,w) quaternions, correcting for
+// the antipodal (q vs -q) ambiguity first so the blend takes the short way
+// around.
+func blendQuat(a, b []float64, t float64) pga.Motor {
+	if a[0]*b[0]+a[1]*b[1]+a[2]*b[2]+a[3]*b[3] < 0 {
+		b = []float64{-b[0], -b[1], -b[2], -b[3]}
+	}
+	return pga.ScLERP(quatMotor(a), quatMotor(b), t)
+}
+
+func lerp(a, b, t float64) float64 { return a + (b-a)*t }
+
+// keyframeIndex finds the keyframe at or before t and the fractional
+// progress toward the next one.
+func keyframeIndex(times []float64, t float64) (int, float64) {
+	n := len(times)
+	if n == 0 {
+		return 0, 0
+	}
+	i := sort.SearchFloat64s(times, t)
+	if i >= n {
+		return n - 1, 0
+	}
+	if times[i] == t || i == 0 {
+		return i, 0
+	}
+	i--
+	span := times[i+1] - times[i]
+	if span <= 0 {
+		return i, 0
+	}
+	return i, (t - times[i]) / span
+}
@@ -0,0 +1,86 @@
+package skin
+
+import (
+	"math"
+
+	"goikga/pga"
+)
+
+// MaxInfluences is the number of joint influences Deform blends per vertex,
+// matching glTF's JOINTS_0/WEIGHTS_0 vec4 attributes.
+const MaxInfluences = 4
+
+// Influence is one (joint, weight) pair for a vertex.
+type Influence struct {
+	Joint  int
+	Weight float64
+}
+
+// Deform applies dual quaternion skinning (DQS) to vertices given their
+// per-vertex influences (up to MaxInfluences each, as produced by glTF's
+// JOINTS_0/WEIGHTS_0) and the skinning motors from Skeleton.WorldMotors.
+func Deform(vertices []pga.Vec3, influences [][]Influence, motors []pga.Motor) []pga.Vec3 {
+	out := make([]pga.Vec3, len(vertices))
+	for i, v := range vertices {
+		m := blend(influences[i], motors)
+		out[i] = m.ActPoint(v)
+	}
+	return out
+}
+
+// blend performs the weighted dual-quaternion sum for a single vertex's
+// influences, correcting antipodal sign flips against the first influence
+// before summing, then renormalizes so r is unit and d is orthogonal to r
+// (Kavan et al., "Skinning with Dual Quaternions").
+func blend(infl []Influence, motors []pga.Motor) pga.Motor {
+	if len(infl) == 0 {
+		return pga.Identity()
+	}
+	var rw, rx, ry, rz, dw, dx, dy, dz float64
+	var r0w float64
+	for i, inf := range infl {
+		if inf.Weight == 0 {
+			continue
+		}
+		r, d := motors[inf.Joint].DQParts()
+		w := inf.Weight
+		if i == 0 {
+			r0w = r[0]
+		} else if r[0]*sign(r0w) < 0 {
+			// Flip to the same hemisphere as the first influence to avoid
+			// antipodal cancellation in the weighted sum.
+			w = -w
+		}
+		rw += w * r[0]
+		rx += w * r[1]
+		ry += w * r[2]
+		rz += w * r[3]
+		dw += w * d[0]
+		dx += w * d[1]
+		dy += w * d[2]
+		dz += w * d[3]
+	}
+
+	rn := math.Sqrt(rw*rw + rx*rx + ry*ry + rz*rz)
+	if rn == 0 {
+		return pga.Identity()
+	}
+	rw, rx, ry, rz = rw/rn, rx/rn, ry/rn, rz/rn
+
+	// Project out the component of d along r so d is orthogonal to r, then
+	// scale by 1/rn to match r's renormalization.
+	dot := rw*dw + rx*dx + ry*dy + rz*dz
+	dw = (dw - dot*rw) / rn
+	dx = (dx - dot*rx) / rn
+	dy = (dy - dot*ry) / rn
+	dz = (dz - dot*rz) / rn
+
+	return pga.MotorFromDQParts([4]float64{rw, rx, ry, rz}, [4]float64{dw, dx, dy, dz})
+}
+
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
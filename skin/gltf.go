@@ -0,0 +1,263 @@
+package skin
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"goikga/pga"
+)
+
+// This loader covers the subset of glTF 2.0 needed to drive Skeleton/Pose/
+// Animator: nodes, one skin's joints and inverseBindMatrices, and animation
+// channels/samplers targeting translation/rotation. It only resolves buffers
+// supplied as embedded base64 data URIs (the common case for small rigged
+// test assets); external .bin files and .glb binary chunks are not handled
+// here and are left as future work, same as the CGA sketch's honest scope
+// notes.
+
+type gltfDocument struct {
+	Nodes       []gltfNode       `json:"nodes"`
+	Skins       []gltfSkin       `json:"skins"`
+	Accessors   []gltfAccessor   `json:"accessors"`
+	BufferViews []gltfBufferView `json:"bufferViews"`
+	Buffers     []gltfBuffer     `json:"buffers"`
+	Animations  []gltfAnimation  `json:"animations"`
+}
+
+type gltfNode struct {
+	Name        string    `json:"name"`
+	Children    []int     `json:"children"`
+	Translation []float64 `json:"translation"`
+	Rotation    []float64 `json:"rotation"` // x,y,z,w
+	Scale       []float64 `json:"scale"`
+}
+
+type gltfSkin struct {
+	Joints              []int `json:"joints"`
+	InverseBindMatrices int   `json:"inverseBindMatrices"`
+}
+
+type gltfAccessor struct {
+	BufferView    int    `json:"bufferView"`
+	ComponentType int    `json:"componentType"`
+	Count         int    `json:"count"`
+	Type          string `json:"type"`
+}
+
+type gltfBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+}
+
+type gltfBuffer struct {
+	URI string `json:"uri"`
+}
+
+type gltfAnimationChannel struct {
+	Sampler int `json:"sampler"`
+	Target  struct {
+		Node int    `json:"node"`
+		Path string `json:"path"`
+	} `json:"target"`
+}
+
+type gltfAnimationSampler struct {
+	Input         int    `json:"input"`
+	Output        int    `json:"output"`
+	Interpolation string `json:"interpolation"` // LINEAR, STEP, CUBICSPLINE
+}
+
+type gltfAnimation struct {
+	Name      string                 `json:"name"`
+	Channels  []gltfAnimationChannel `json:"channels"`
+	Samplers  []gltfAnimationSampler `json:"samplers"`
+}
+
+// LoadGLTF parses a .gltf JSON document from path and builds a Skeleton from
+// its first skin, plus the raw document (for Animator). Joint i of the
+// Skeleton is node doc.Skins[0].Joints[i].
+func LoadGLTF(path string) (*Skeleton, *gltfDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var doc gltfDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Skins) == 0 {
+		return nil, nil, fmt.Errorf("skin: glTF document has no skins")
+	}
+	skin := doc.Skins[0]
+
+	invBind, err := doc.readMat4Accessor(skin.InverseBindMatrices)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Map glTF node index -> joint index within skin.Joints.
+	nodeToJoint := make(map[int]int, len(skin.Joints))
+	for ji, ni := range skin.Joints {
+		nodeToJoint[ni] = ji
+	}
+
+	joints := make([]Joint, len(skin.Joints))
+	for ji, ni := range skin.Joints {
+		n := doc.Nodes[ni]
+		parent := -1
+		for pn, pnode := range doc.Nodes {
+			for _, c := range pnode.Children {
+				if c == ni {
+					if pj, ok := nodeToJoint[pn]; ok {
+						parent = pj
+					}
+				}
+			}
+		}
+		if len(n.Scale) == 3 && (n.Scale[0] != 1 || n.Scale[1] != 1 || n.Scale[2] != 1) {
+			fmt.Fprintf(os.Stderr, "skin: node %q has non-unit scale %v, rejected (motors are rigid)\n", n.Name, n.Scale)
+		}
+		joints[ji] = Joint{
+			Name:        n.Name,
+			Parent:      parent,
+			Rest:        nodeMotor(n),
+			InverseBind: motorFromInverseBindMat4(invBind[ji]),
+		}
+	}
+	return &Skeleton{Joints: joints}, &doc, nil
+}
+
+// nodeMotor builds a rest-pose motor from a glTF node's TRS.
+func nodeMotor(n gltfNode) pga.Motor {
+	m := pga.Identity()
+	if len(n.Rotation) == 4 {
+		x, y, z, w := n.Rotation[0], n.Rotation[1], n.Rotation[2], n.Rotation[3]
+		theta := 2 * math.Acos(clamp(w, -1, 1))
+		s := math.Sqrt(1 - w*w)
+		axis := pga.V(x, y, z)
+		if s > 1e-9 {
+			axis = axis.Scale(1 / s)
+		} else {
+			axis = pga.V(1, 0, 0)
+		}
+		m = pga.FromAxisAngle(axis, theta)
+	}
+	if len(n.Translation) == 3 {
+		m = pga.Translator(pga.V(n.Translation[0], n.Translation[1], n.Translation[2])).Mul(m)
+	}
+	return m
+}
+
+func clamp(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// motorFromInverseBindMat4 extracts the rigid part of a 4x4 inverse bind
+// matrix (column-major, as glTF stores it) as a motor, ignoring any residual
+// scale/skew beyond rounding error.
+func motorFromInverseBindMat4(mat [16]float64) pga.Motor {
+	// Column-major 4x4: columns 0..2 are the rotation basis, column 3 is
+	// translation.
+	m00, m01, m02 := mat[0], mat[4], mat[8]
+	m10, m11, m12 := mat[1], mat[5], mat[9]
+	m20, m21, m22 := mat[2], mat[6], mat[10]
+	tx, ty, tz := mat[12], mat[13], mat[14]
+
+	// Standard matrix->quaternion (Shepperd's method).
+	trace := m00 + m11 + m22
+	var w, x, y, z float64
+	if trace > 0 {
+		s := 0.5 / math.Sqrt(trace+1)
+		w = 0.25 / s
+		x = (m21 - m12) * s
+		y = (m02 - m20) * s
+		z = (m10 - m01) * s
+	} else if m00 > m11 && m00 > m22 {
+		s := 2 * math.Sqrt(1+m00-m11-m22)
+		w = (m21 - m12) / s
+		x = 0.25 * s
+		y = (m01 + m10) / s
+		z = (m02 + m20) / s
+	} else if m11 > m22 {
+		s := 2 * math.Sqrt(1+m11-m00-m22)
+		w = (m02 - m20) / s
+		x = (m01 + m10) / s
+		y = 0.25 * s
+		z = (m12 + m21) / s
+	} else {
+		s := 2 * math.Sqrt(1+m22-m00-m11)
+		w = (m10 - m01) / s
+		x = (m02 + m20) / s
+		y = (m12 + m21) / s
+		z = 0.25 * s
+	}
+	theta := 2 * math.Acos(clamp(w, -1, 1))
+	sn := math.Sqrt(1 - w*w)
+	axis := pga.V(x, y, z)
+	if sn > 1e-9 {
+		axis = axis.Scale(1 / sn)
+	} else {
+		axis = pga.V(1, 0, 0)
+	}
+	rot := pga.FromAxisAngle(axis, theta)
+	return pga.Translator(pga.V(tx, ty, tz)).Mul(rot)
+}
+
+// readMat4Accessor reads a MAT4/FLOAT accessor as a slice of column-major
+// 4x4 matrices, resolving its bufferView through an embedded base64 buffer.
+func (doc *gltfDocument) readMat4Accessor(idx int) ([][16]float64, error) {
+	floats, err := doc.readFloats(idx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][16]float64, len(floats)/16)
+	for i := range out {
+		copy(out[i][:], floats[i*16:i*16+16])
+	}
+	return out, nil
+}
+
+// readFloats reads a FLOAT accessor's flat component values.
+func (doc *gltfDocument) readFloats(accessorIdx int) ([]float64, error) {
+	if accessorIdx < 0 || accessorIdx >= len(doc.Accessors) {
+		return nil, fmt.Errorf("skin: accessor index %d out of range", accessorIdx)
+	}
+	acc := doc.Accessors[accessorIdx]
+	bv := doc.BufferViews[acc.BufferView]
+	buf := doc.Buffers[bv.Buffer]
+
+	raw, err := decodeDataURI(buf.URI)
+	if err != nil {
+		return nil, fmt.Errorf("skin: %w (only embedded base64 buffers are supported)", err)
+	}
+	chunk := raw[bv.ByteOffset : bv.ByteOffset+bv.ByteLength]
+
+	n := len(chunk) / 4
+	out := make([]float64, n)
+	for i := 0; i < n; i++ {
+		bits := binary.LittleEndian.Uint32(chunk[i*4:])
+		out[i] = float64(math.Float32frombits(bits))
+	}
+	return out, nil
+}
+
+func decodeDataURI(uri string) ([]byte, error) {
+	const marker = ";base64,"
+	i := strings.Index(uri, marker)
+	if !strings.HasPrefix(uri, "data:") || i < 0 {
+		return nil, fmt.Errorf("unsupported buffer URI %q", uri)
+	}
+	return base64.StdEncoding.DecodeString(uri[i+len(marker):])
+}
@@ -0,0 +1,59 @@
+// Package skin implements skeletal mesh deformation driven entirely by
+// pga.Motor sandwich products rather than 4x4 matrices, following the
+// "no matrices" PGA renderer approach: joints are rigid motors, composing a
+// skeleton is motor multiplication, and skinning a vertex is a dual
+// quaternion blend instead of a weighted matrix sum.
+package skin
+
+import "goikga/pga"
+
+// Joint is one bone in a Skeleton.
+type Joint struct {
+	Name   string
+	Parent int // index into Skeleton.Joints, or -1 for a root joint
+	// Rest is the joint's local rest-pose motor, relative to Parent.
+	Rest pga.Motor
+	// InverseBind is the inverse bind motor (model-space-to-joint-space at
+	// bind time), as carried by glTF's inverseBindMatrices.
+	InverseBind pga.Motor
+}
+
+// Skeleton is an ordered list of joints; Joints must be topologically sorted
+// so that Parent < index for every non-root joint (as glTF skins already
+// guarantee via node hierarchy traversal order).
+type Skeleton struct {
+	Joints []Joint
+}
+
+// Pose is a set of local joint transforms, one per Skeleton.Joints entry.
+type Pose []pga.Motor
+
+// RestPose returns the skeleton's bind pose as a Pose.
+func (s *Skeleton) RestPose() Pose {
+	pose := make(Pose, len(s.Joints))
+	for i, j := range s.Joints {
+		pose[i] = j.Rest
+	}
+	return pose
+}
+
+// WorldMotors composes pose along the parent chain into world-space motors,
+// then pre-multiplies each by its joint's inverse bind motor to yield the
+// skinning motors DQS needs: skin[i] = world[i] * inverseBind[i].
+func (s *Skeleton) WorldMotors(pose Pose) []pga.Motor {
+	world := make([]pga.Motor, len(s.Joints))
+	skinning := make([]pga.Motor, len(s.Joints))
+	for i, j := range s.Joints {
+		local := pga.Identity()
+		if i < len(pose) {
+			local = pose[i]
+		}
+		if j.Parent < 0 {
+			world[i] = local
+		} else {
+			world[i] = world[j.Parent].Mul(local)
+		}
+		skinning[i] = world[i].Mul(j.InverseBind)
+	}
+	return skinning
+}